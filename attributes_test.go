@@ -132,7 +132,7 @@ func TestUniversalAttributes(t *testing.T) {
 			}{Timeout: 30 * time.Second},
 			want: `<form method="POST">
 <label for="timeout">Timeout</label>
-<input type="number" name="timeout" value="30" min="1" max="3600" id="timeout" required>
+<input type="number" name="timeout" value="30" min="1" max="3600" step="any" id="timeout" required>
 </form>
 `,
 		},
@@ -316,14 +316,14 @@ func selectRandomOption(previous []int) (string, string, []int) {
 func buildExpected(config FieldConfig) string {
 	buf := &strings.Builder{}
 	if id, ok := config.Attributes["id"]; ok {
-		buf.WriteString(fmt.Sprintf(` id="%s"`, escapeHTML(id)))
+		buf.WriteString(fmt.Sprintf(` id="%s"`, escapeText(id)))
 	} else {
-		buf.WriteString(fmt.Sprintf(` id="%s"`, escapeHTML(config.Name)))
+		buf.WriteString(fmt.Sprintf(` id="%s"`, escapeText(config.Name)))
 	}
 
 	// Add placeholder attribute
 	if placeholder, ok := config.Attributes["placeholder"]; ok {
-		buf.WriteString(fmt.Sprintf(` placeholder="%s"`, escapeHTML(placeholder)))
+		buf.WriteString(fmt.Sprintf(` placeholder="%s"`, escapeText(placeholder)))
 	}
 
 	// Add boolean attributes (required, readonly, disabled)
@@ -351,8 +351,11 @@ func TestUniversalAttributePermutations(t *testing.T) {
 			key, value, previous = selectRandomOption(previous)
 			config.Attributes[key] = value
 		}
+		var f Field
+		applyUniversalAttributes(&f, config)
 		output := &strings.Builder{}
-		addUniversalAttributes(output, config)
+		hw := htmlWriter{w: output}
+		hw.commonAttrs(&f, f.ID)
 		expected := buildExpected(config)
 		if output.String() != expected {
 			t.Errorf("iteration %d failed. expected '%s', got '%s'", i, expected, output.String())
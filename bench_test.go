@@ -0,0 +1,52 @@
+package vee
+
+import (
+	"testing"
+	"time"
+)
+
+type benchForm struct {
+	Name         string `vee:"required,placeholder:'Your name'" css:"input" labelCss:"label"`
+	Email        string `vee:"type:'email',required"`
+	Age          int    `vee:"min:18,max:120"`
+	Score        float64
+	Active       bool
+	Birthday     time.Time `vee:"type:'date'"`
+	Timeout      time.Duration
+	Website      *string `vee:"type:'url'"`
+	ColorChoices []string
+	ColorChosen  int
+	SizeChoices  []string
+	SizeChosen   int `vee:"type:'radio'"`
+	Phase        int `vee:"hidden"`
+}
+
+// benchFields is the number of rendered fields in benchForm
+const benchFields = 11
+
+func newBenchForm() benchForm {
+	return benchForm{
+		Name:         "John <Doe>",
+		Email:        "john@example.com",
+		Age:          30,
+		Score:        9.5,
+		Active:       true,
+		Birthday:     time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC),
+		Timeout:      30 * time.Second,
+		ColorChoices: []string{"Red", "Green", "Blue"},
+		ColorChosen:  1,
+		SizeChoices:  []string{"S", "M", "L"},
+		SizeChosen:   2,
+		Phase:        3,
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	form := newBenchForm()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := Render(form); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,36 +1,82 @@
 package vee
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
 // BindRequest parses HTTP form data and populates the provided struct.
-// It automatically calls ParseForm() and handles both GET and POST form data.
-func BindRequest(r *http.Request, v any) error {
-	if err := r.ParseForm(); err != nil {
+// It handles both GET and POST form data, and parses multipart/form-data
+// bodies including uploaded files, keeping up to BindOption.MaxMemory bytes
+// of them in memory.
+func BindRequest(r *http.Request, v any, opts ...BindOption) error {
+	// The request context and its location apply unless set by an option
+	opts = append([]BindOption{{Context: r.Context(), Location: LocationFromContext(r.Context())}}, opts...)
+	options := consolidateBindOptions(opts...)
+
+	// ParseMultipartForm parses the form before checking the content type
+	err := r.ParseMultipartForm(options.maxMemory())
+	if errors.Is(err, http.ErrNotMultipart) {
+		return Bind(map[string][]string(r.Form), v, opts...)
+	}
+	if err != nil {
 		return fmt.Errorf("vee: failed to parse form: %w", err)
 	}
-	return Bind(map[string][]string(r.Form), v)
+	return Bind(&multipart.Form{Value: r.Form, File: r.MultipartForm.File}, v, opts...)
 }
 
 // Bind parses form data and populates the provided struct.
 // The struct pointer v will be populated with form data.
-func Bind(r any, v any) error {
-	// Accept both url.Values and map[string][]string
+//
+// Structural problems (v is not a pointer to a struct, invalid Choices/Chosen
+// fields) are returned immediately and match the sentinel errors with errors.Is.
+// Values that cannot be parsed are collected across all fields and returned
+// together as BindErrors.
+//
+// Fields of nested structs are bound from dotted names (address.street). Nil
+// struct pointers are only allocated when the form data contains any of their
+// fields.
+func Bind(r any, v any, opts ...BindOption) error {
+	// Accept url.Values, map[string][]string and parsed multipart forms
 	var values map[string][]string
+	var files map[string][]*multipart.FileHeader
 	switch formData := r.(type) {
 	case url.Values:
 		values = map[string][]string(formData)
 	case map[string][]string:
 		values = formData
+	case *multipart.Form:
+		values = formData.Value
+		files = formData.File
 	default:
-		return fmt.Errorf("vee: expected url.Values or map[string][]string, got %T", r)
+		return fmt.Errorf("%w, got %T", ErrFormData, r)
+	}
+
+	if len(files) > 0 {
+		// Add the names of uploaded files so that nested structs and rows
+		// with only file fields are detected
+		values = maps.Clone(values)
+		if values == nil {
+			values = make(map[string][]string, len(files))
+		}
+		for name := range files {
+			if _, exists := values[name]; !exists {
+				values[name] = nil
+			}
+		}
 	}
 
 	val := reflect.ValueOf(v)
@@ -38,55 +84,94 @@ func Bind(r any, v any) error {
 
 	// Must be pointer to struct
 	if typ.Kind() != reflect.Ptr {
-		return fmt.Errorf("vee: expected pointer to struct, got %v", typ.Kind())
+		return fmt.Errorf("%w, got %v", ErrNotPointer, typ.Kind())
 	}
 
 	val = val.Elem()
 	typ = typ.Elem()
 
 	if typ.Kind() != reflect.Struct {
-		return fmt.Errorf("vee: expected pointer to struct, got pointer to %v", typ.Kind())
+		return fmt.Errorf("%w, got pointer to %v", ErrNotStruct, typ.Kind())
 	}
 
-	// Validate Choices/Chosen pairs
-	choicesChosenPairs, err := validateChoicesChosen(typ, val)
-	if err != nil {
+	options := consolidateBindOptions(opts...)
+
+	var bindErrs BindErrors
+	if err := bindStruct(values, files, val, schemaFor(typ), options, fieldScope{sep: options.nameSeparator()}, &bindErrs); err != nil {
 		return err
 	}
 
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-		fieldVal := val.Field(i)
+	if len(bindErrs) > 0 {
+		return bindErrs
+	}
+	return nil
+}
+
+// bindStruct binds form data to the fields of a struct value, collecting
+// parse errors in bindErrs. Structural errors are returned.
+func bindStruct(values map[string][]string, files map[string][]*multipart.FileHeader, val reflect.Value, schema *typeSchema, options *BindOption, scope fieldScope, bindErrs *BindErrors) error {
+	if schema.err != nil {
+		return schema.err
+	}
 
-		// Skip unexported fields
-		if !field.IsExported() {
+	// Provide and validate Choices/Chosen values. Provided choices are
+	// stored in the Choices fields, which the bound Chosen fields refer to.
+	// Pairs depending on another are resolved once their parent is bound.
+	ctx := options.context()
+	validated := schema.validatedPairs(scope, options.choicesField)
+	pairs := make([]ChoicesChosenPair, len(schema.pairs))
+	for p := range pairs {
+		if schema.pairs[p].parent >= 0 {
 			continue
 		}
+		if err := schema.resolvePair(ctx, val, pairs, p, validated[p]); err != nil {
+			return err
+		}
+		storeChoices(val, pairs[p])
+	}
 
-		// Parse vee tag
-		veeTag := field.Tag.Get("vee")
-		config := parseVeeTag(veeTag, field.Name)
-
-		// Skip if requested
-		if config.Skip {
-			continue
+	// Dependent Chosen fields, bound after all others
+	type dependentField struct {
+		fs       *fieldSchema
+		fieldVal reflect.Value
+		config   FieldConfig
+		path     string
+	}
+	var dependents []dependentField
+
+	for i := range schema.fields {
+		fs := &schema.fields[i]
+		field := fs.field
+		path := scope.fieldPath(field.Name)
+		config := fs.config
+		config.Name = scope.fieldName(config.Name)
+		fieldVal := fs.value(val)
+		if fs.embedPointer {
+			// Only allocate nil embedded struct pointers if the field was submitted
+			if _, err := val.FieldByIndexErr(field.Index); err != nil && !hasFormData(values, config.Name, scope.sep) {
+				continue
+			}
+			if fieldVal = fs.settableValue(val); !fieldVal.IsValid() {
+				continue
+			}
 		}
 
 		// Skip Choices fields (they're not bound from form data)
-		if strings.HasSuffix(field.Name, "Choices") {
+		if fs.kind == kindChoices {
 			continue
 		}
 
 		// Handle Chosen fields specially
-		if strings.HasSuffix(field.Name, "Chosen") {
-			baseName := strings.TrimSuffix(field.Name, "Chosen")
-			if pair, exists := choicesChosenPairs[baseName]; exists {
-				err := bindMultiValueField(values, fieldVal, pair, config)
-				if err != nil {
-					return err
-				}
-				continue
+		if fs.kind == kindChosen && pairs[fs.pair].parent >= 0 {
+			dependents = append(dependents, dependentField{fs, fieldVal, config, path})
+			continue
+		}
+		if fs.kind == kindChosen {
+			if fieldErr := bindMultiValueField(values, fieldVal, pairs[fs.pair], config); fieldErr != nil {
+				fieldErr.Field = path
+				*bindErrs = append(*bindErrs, fieldErr)
 			}
+			continue
 		}
 
 		// Bind based on field type
@@ -94,17 +179,20 @@ func Bind(r any, v any) error {
 			continue
 		}
 
-		// Handle pointer types
-		actualType := field.Type
-		isPointer := false
-
-		if actualType.Kind() == reflect.Ptr {
-			isPointer = true
-			actualType = actualType.Elem()
+		// Hidden fields use the hidden value codec
+		if config.Hidden {
+			if fieldErr := bindHiddenField(values, fieldVal, fs, config); fieldErr != nil {
+				fieldErr.Field = path
+				*bindErrs = append(*bindErrs, fieldErr)
+			}
+			continue
 		}
 
-		// Check for specific types first (before generic kind matching)
-		if actualType == reflect.TypeOf(time.Time{}) {
+		isPointer := fs.pointer
+
+		// Bind based on the resolved field kind
+		switch fs.kind {
+		case kindTime:
 			// For time fields, skip if no form data
 			formValues, exists := values[config.Name]
 			if !exists || len(formValues) == 0 {
@@ -113,29 +201,11 @@ func Bind(r any, v any) error {
 
 			formValue := formValues[0]
 
-			// Determine expected format based on type attribute
-			inputType := "datetime-local" // default
-			if typeAttr, ok := config.Attributes["type"]; ok {
-				switch typeAttr {
-				case "date", "datetime-local", "time":
-					inputType = typeAttr
-				}
-			}
-
-			// Parse based on input type
-			var timeVal time.Time
-			var err error
-			switch inputType {
-			case "date":
-				timeVal, err = time.Parse("2006-01-02", formValue)
-			case "time":
-				timeVal, err = time.Parse("15:04", formValue)
-			case "datetime-local":
-				timeVal, err = time.Parse("2006-01-02T15:04", formValue)
-			}
-
+			// Parse based on the input type
+			timeVal, err := fs.time.parse(formValue, fieldLocation(fs, options.Location))
 			if err != nil {
-				return fmt.Errorf("vee: cannot parse '%s' as time for field '%s': %w", formValue, config.Name, err)
+				*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: formValue, Kind: KindTime, Err: err})
+				continue
 			}
 
 			if isPointer {
@@ -143,10 +213,8 @@ func Bind(r any, v any) error {
 			} else {
 				fieldVal.Set(reflect.ValueOf(timeVal))
 			}
-			continue
-		}
 
-		if actualType == reflect.TypeOf(time.Duration(0)) {
+		case kindDuration:
 			// For duration fields, skip if no form data
 			formValues, exists := values[config.Name]
 			if !exists || len(formValues) == 0 {
@@ -155,31 +223,25 @@ func Bind(r any, v any) error {
 
 			formValue := formValues[0]
 
-			// Get units (default to seconds)
-			units := "s"
-			if unitsAttr, ok := config.Attributes["units"]; ok {
-				switch unitsAttr {
-				case "ms", "s", "m", "h":
-					units = unitsAttr
+			var duration time.Duration
+			var err error
+			if fs.duration.text {
+				duration, err = time.ParseDuration(formValue)
+			} else {
+				// The units select overrides the units of the field
+				unit := fs.duration.unit
+				if units := values[unitsName(config.Name)]; fs.duration.selectable && len(units) > 0 {
+					if _, ok := unitSize(units[0]); !ok {
+						*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: unitsName(config.Name), Value: units[0], Kind: KindDuration, Err: fmt.Errorf("unknown duration unit '%s'", units[0])})
+						continue
+					}
+					unit = units[0]
 				}
+				duration, err = parseDuration(formValue, unit)
 			}
-
-			// Parse the numeric value and multiply by unit constant
-			floatVal, err := strconv.ParseFloat(formValue, 64)
 			if err != nil {
-				return fmt.Errorf("vee: cannot parse '%s' as duration for field '%s': %w", formValue, config.Name, err)
-			}
-
-			var duration time.Duration
-			switch units {
-			case "ms":
-				duration = time.Duration(floatVal) * time.Millisecond
-			case "s":
-				duration = time.Duration(floatVal) * time.Second
-			case "m":
-				duration = time.Duration(floatVal) * time.Minute
-			case "h":
-				duration = time.Duration(floatVal) * time.Hour
+				*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: formValue, Kind: KindDuration, Err: err})
+				continue
 			}
 
 			if isPointer {
@@ -187,22 +249,99 @@ func Bind(r any, v any) error {
 			} else {
 				fieldVal.Set(reflect.ValueOf(duration))
 			}
-			continue
-		}
 
-		switch actualType.Kind() {
-		case reflect.Bool:
+		case kindStruct:
+			structVal := fieldVal
+			if isPointer {
+				if fieldVal.IsNil() {
+					// Only allocate nil struct pointers if any of their fields were submitted
+					if !hasFieldsWithPrefix(values, config.Name+scope.sep) {
+						continue
+					}
+					fieldVal.Set(reflect.New(field.Type.Elem()))
+				}
+				structVal = fieldVal.Elem()
+			}
+
+			nested := scope.nest(config.Name, "", path)
+			if err := bindStruct(values, files, structVal, schemaFor(fs.elem), options, nested, bindErrs); err != nil {
+				return err
+			}
+
+		case kindStructSlice:
+			if err := bindRows(values, files, fieldVal, fs, config.Name, path, options, scope, bindErrs); err != nil {
+				return err
+			}
+
+		case kindBool:
 			// For checkboxes: present in form data = true, absent = false
 			formValues, exists := values[config.Name]
 			boolVal := exists && len(formValues) > 0
 
-			if isPointer {
-				fieldVal.Set(reflect.ValueOf(&boolVal))
+			settableValue(fieldVal, isPointer).SetBool(boolVal)
+
+		case kindFile, kindFiles:
+			// Files that were not uploaded leave the field unchanged
+			headers := files[config.Name]
+			if len(headers) == 0 {
+				continue
+			}
+
+			if err := checkFiles(headers, fs); err != nil {
+				*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: err.filename, Kind: KindFile, Err: err})
+				continue
+			}
+
+			if fs.kind == kindFile {
+				fieldVal.Set(reflect.ValueOf(headers[0]))
 			} else {
-				fieldVal.SetBool(boolVal)
+				fieldVal.Set(reflect.ValueOf(slices.Clone(headers)))
 			}
 
-		default:
+		case kindCodec:
+			formValues, exists := values[config.Name]
+			if !exists || len(formValues) == 0 {
+				continue
+			}
+
+			// Bind into a copy so that errors leave the field unchanged
+			valueType := field.Type
+			if isPointer {
+				valueType = valueType.Elem()
+			}
+			target := reflect.New(valueType).Elem()
+			if !isPointer {
+				target.Set(fieldVal)
+			} else if !fieldVal.IsNil() {
+				target.Set(fieldVal.Elem())
+			}
+			if err := fs.codec.Bind(target, formValues); err != nil {
+				*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: formValues[0], Kind: valueType.String(), Err: err})
+				continue
+			}
+
+			settableValue(fieldVal, isPointer).Set(target)
+
+		case kindText:
+			formValues, exists := values[config.Name]
+			if !exists || len(formValues) == 0 {
+				continue
+			}
+
+			// Unmarshal into a new value so that errors leave the field unchanged
+			valueType := field.Type
+			if isPointer {
+				valueType = valueType.Elem()
+			}
+			ptr := reflect.New(valueType)
+			if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(formValues[0])); err != nil {
+				*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: formValues[0], Kind: KindText, Err: err})
+				continue
+			}
+
+			settableValue(fieldVal, isPointer).Set(ptr.Elem())
+
+		case kindString, kindInt, kindUint, kindFloat:
 			// For non-boolean fields, skip if no form data
 			formValues, exists := values[config.Name]
 			if !exists || len(formValues) == 0 {
@@ -210,91 +349,434 @@ func Bind(r any, v any) error {
 			}
 
 			formValue := formValues[0]
+			valueType := field.Type
+			if isPointer {
+				valueType = valueType.Elem()
+			}
 
-			switch actualType.Kind() {
-			case reflect.String:
-				if isPointer {
-					fieldVal.Set(reflect.ValueOf(&formValue))
-				} else {
-					fieldVal.SetString(formValue)
+			switch fs.kind {
+			case kindString:
+				switch config.Attributes["type"] {
+				case "textarea":
+					// Browsers submit textarea line breaks as CRLF
+					formValue = normalizeNewlines(formValue)
+				case "color":
+					if !isHexColor(formValue) {
+						*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: formValue, Kind: KindColor, Err: errColor})
+						continue
+					}
+					formValue = strings.ToLower(formValue)
 				}
 
-			case reflect.Int, reflect.Int64:
-				intVal, err := strconv.ParseInt(formValue, 10, 64)
+				settableValue(fieldVal, isPointer).SetString(formValue)
+
+			case kindInt:
+				intVal, err := strconv.ParseInt(formValue, 10, valueType.Bits())
 				if err != nil {
-					return fmt.Errorf("vee: cannot parse '%s' as integer for field '%s': %w", formValue, config.Name, err)
+					*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: formValue, Kind: KindInteger, Err: numberError(err, formValue, valueType)})
+					continue
 				}
 
-				if isPointer {
-					if actualType.Kind() == reflect.Int {
-						intPtr := int(intVal)
-						fieldVal.Set(reflect.ValueOf(&intPtr))
-					} else {
-						fieldVal.Set(reflect.ValueOf(&intVal))
-					}
-				} else {
-					fieldVal.SetInt(intVal)
-				}
+				settableValue(fieldVal, isPointer).SetInt(intVal)
 
-			case reflect.Float64:
-				floatVal, err := strconv.ParseFloat(formValue, 64)
+			case kindUint:
+				uintVal, err := parseUint(formValue, valueType.Bits())
 				if err != nil {
-					return fmt.Errorf("vee: cannot parse '%s' as float for field '%s': %w", formValue, config.Name, err)
+					*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: formValue, Kind: KindInteger, Err: numberError(err, formValue, valueType)})
+					continue
 				}
 
-				if isPointer {
-					fieldVal.Set(reflect.ValueOf(&floatVal))
-				} else {
-					fieldVal.SetFloat(floatVal)
+				settableValue(fieldVal, isPointer).SetUint(uintVal)
+
+			case kindFloat:
+				floatVal, err := strconv.ParseFloat(formValue, valueType.Bits())
+				if err != nil {
+					*bindErrs = append(*bindErrs, &FieldError{Field: path, Name: config.Name, Value: formValue, Kind: KindFloat, Err: numberError(err, formValue, valueType)})
+					continue
 				}
 
+				settableValue(fieldVal, isPointer).SetFloat(floatVal)
 			}
 		}
 	}
 
+	// Bind dependent Chosen fields after their parents, against the choices
+	// for the submitted parent. A choice kept from before binding must be
+	// among them as well.
+	slices.SortFunc(dependents, func(a, b dependentField) int {
+		return a.fs.pair - b.fs.pair
+	})
+	for _, d := range dependents {
+		if err := schema.resolvePair(ctx, val, pairs, d.fs.pair, false); err != nil {
+			return err
+		}
+		pair := pairs[d.fs.pair]
+		storeChoices(val, pair)
+
+		fieldErr := bindMultiValueField(values, d.fieldVal, pair, d.config)
+		if fieldErr == nil && validated[d.fs.pair] {
+			if err := validateChoicesValues(pair); err != nil {
+				chosen, _ := pair.chosenValues()
+				fieldErr = choiceError(pair, d.config, strings.Join(chosen, ","), err)
+			}
+		}
+		if fieldErr != nil {
+			fieldErr.Field = d.path
+			*bindErrs = append(*bindErrs, fieldErr)
+		}
+	}
+
 	return nil
 }
 
-// bindMultiValueField binds form data to a Chosen field
-func bindMultiValueField(values map[string][]string, fieldVal reflect.Value, pair ChoicesChosenPair, config FieldConfig) error {
-	formValues, exists := values[config.Name]
-	if !exists || len(formValues) == 0 {
-		return nil // No form data, leave field unchanged
+// storeChoices stores the choices of a pair, which may come from its
+// provider, in its Choices field
+func storeChoices(val reflect.Value, pair ChoicesChosenPair) {
+	if choicesVal := fieldValue(val, pair.ChoicesField); choicesVal.CanSet() {
+		choicesVal.Set(pair.ChoicesValue)
 	}
+}
 
-	if pair.IsMultiSelect {
-		// Multi-select: bind []int
-		var indices []int
-		for _, formValue := range formValues {
-			index, err := strconv.Atoi(formValue)
-			if err != nil {
-				return fmt.Errorf("vee: invalid index '%s' for multi-select field '%s'", formValue, config.Name)
+// bindRows binds form data to a slice of structs. The slice is rebuilt from the
+// submitted rows in the order of their indices, so rows may be sparse,
+// reordered or deleted on the client. Rows whose index existed before start
+// from the existing element, new rows from the zero value.
+func bindRows(values map[string][]string, files map[string][]*multipart.FileHeader, fieldVal reflect.Value, fs *fieldSchema, name, path string, options *BindOption, scope fieldScope, bindErrs *BindErrors) error {
+	// Leave the slice unchanged unless its presence input or rows were
+	// submitted
+	indices := rowIndices(values, name, scope.sep)
+	if _, submitted := values[name]; !submitted && len(indices) == 0 {
+		return nil
+	}
+
+	if (fs.maxRows > 0 && len(indices) > fs.maxRows) || len(indices) < fs.minRows {
+		*bindErrs = append(*bindErrs, &FieldError{
+			Field: path,
+			Name:  name,
+			Value: strconv.Itoa(len(indices)),
+			Kind:  KindRows,
+			Err:   &rowCountError{count: len(indices), min: fs.minRows, max: fs.maxRows},
+		})
+		return nil
+	}
+
+	if len(indices) == 0 {
+		fieldVal.Set(reflect.Zero(fieldVal.Type()))
+		return nil
+	}
+
+	schema := schemaFor(fs.elem)
+	rows := reflect.MakeSlice(fieldVal.Type(), len(indices), len(indices))
+	for i, index := range indices {
+		row := rows.Index(i)
+		existing := index < fieldVal.Len()
+		if existing {
+			row.Set(fieldVal.Index(index))
+		}
+		if fs.elemPointer {
+			if row.IsNil() {
+				row.Set(reflect.New(fs.elem))
+			}
+			row = row.Elem()
+		}
+
+		// Errors refer to the position of the row in the bound slice
+		rowScope := scope.row(name, "", path, strconv.Itoa(index), !existing)
+		rowScope.field = path + "[" + strconv.Itoa(i) + "]"
+		if err := bindStruct(values, files, row, schema, options, rowScope, bindErrs); err != nil {
+			return err
+		}
+	}
+	fieldVal.Set(rows)
+
+	return nil
+}
+
+// rowIndices returns the sorted, distinct row indices of a slice field found
+// in the form data names (items[3].sku)
+func rowIndices(values map[string][]string, name, sep string) []int {
+	prefix := name + "["
+	var indices []int
+	for key := range values {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		digits, rest, ok := strings.Cut(rest, "]")
+		if !ok || digits == "" || !strings.HasPrefix(rest, sep) {
+			continue
+		}
+		if strings.TrimLeft(digits, "0123456789") != "" {
+			continue
+		}
+		index, err := strconv.Atoi(digits)
+		if err != nil {
+			continue
+		}
+		indices = append(indices, index)
+	}
+
+	slices.Sort(indices)
+	return slices.Compact(indices)
+}
+
+// settableValue returns the value to set for a field. For pointer fields it
+// allocates a new value and points the field to it.
+func settableValue(fieldVal reflect.Value, isPointer bool) reflect.Value {
+	if !isPointer {
+		return fieldVal
+	}
+	ptr := reflect.New(fieldVal.Type().Elem())
+	fieldVal.Set(ptr)
+	return ptr.Elem()
+}
+
+// parseUint parses an unsigned integer, reporting negative integers as out of
+// range instead of as syntax errors
+func parseUint(s string, bitSize int) (uint64, error) {
+	uintVal, err := strconv.ParseUint(s, 10, bitSize)
+	if err != nil && strings.HasPrefix(s, "-") {
+		if _, intErr := strconv.ParseInt(s, 10, 64); intErr == nil || errors.Is(intErr, strconv.ErrRange) {
+			return 0, &strconv.NumError{Func: "ParseUint", Num: s, Err: strconv.ErrRange}
+		}
+	}
+	return uintVal, err
+}
+
+// numberError converts a strconv range error into a RangeError reporting the
+// exceeded bound of the numeric type typ
+func numberError(err error, formValue string, typ reflect.Type) error {
+	if !errors.Is(err, strconv.ErrRange) {
+		return err
+	}
+
+	bits := typ.Bits()
+	negative := strings.HasPrefix(formValue, "-")
+	rangeErr := &RangeError{Max: !negative, Err: err}
+	switch {
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Int64:
+		if negative {
+			rangeErr.Limit = strconv.FormatInt(math.MinInt64>>(64-bits), 10)
+		} else {
+			rangeErr.Limit = strconv.FormatInt(math.MaxInt64>>(64-bits), 10)
+		}
+	case typ.Kind() >= reflect.Uint && typ.Kind() <= reflect.Uint64:
+		if negative {
+			rangeErr.Limit = "0"
+		} else {
+			rangeErr.Limit = strconv.FormatUint(math.MaxUint64>>(64-bits), 10)
+		}
+	default:
+		maxFloat := math.MaxFloat64
+		if bits == 32 {
+			maxFloat = math.MaxFloat32
+		}
+		if negative {
+			maxFloat = -maxFloat
+		}
+		rangeErr.Limit = strconv.FormatFloat(maxFloat, 'g', -1, bits)
+	}
+	return rangeErr
+}
+
+// checkFiles checks the uploaded files of a field against its maxsize and
+// accept attributes
+func checkFiles(headers []*multipart.FileHeader, fs *fieldSchema) *fileError {
+	accept := fs.config.Attributes["accept"]
+	for _, header := range headers {
+		if fs.maxSize > 0 && header.Size > fs.maxSize {
+			return &fileError{filename: header.Filename, maxSize: fs.maxSize}
+		}
+		if accept != "" && !acceptsFile(accept, header) {
+			return &fileError{filename: header.Filename, accept: accept}
+		}
+	}
+	return nil
+}
+
+// acceptsFile reports whether a file matches an accept attribute, a comma
+// separated list of file extensions (.pdf), MIME types (image/png) and MIME
+// type wildcards (image/*). The MIME type is the one sent by the client.
+func acceptsFile(accept string, header *multipart.FileHeader) bool {
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	mediaType, _, _ := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	for pattern := range strings.SplitSeq(accept, ",") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		switch {
+		case strings.HasPrefix(pattern, "."):
+			if ext == pattern {
+				return true
 			}
-			// Validate index is in range
-			if index < 0 || index >= pair.ChoicesValue.Len() {
-				return fmt.Errorf("vee: index %d out of range for %d choices in field '%s'", index, pair.ChoicesValue.Len(), config.Name)
+		case strings.HasSuffix(pattern, "/*"):
+			if strings.HasPrefix(mediaType, pattern[:len(pattern)-1]) {
+				return true
 			}
-			indices = append(indices, index)
+		case pattern != "" && mediaType == pattern:
+			return true
 		}
+	}
+	return false
+}
 
-		// Set the slice
-		sliceVal := reflect.MakeSlice(fieldVal.Type(), len(indices), len(indices))
-		for i, index := range indices {
-			sliceVal.Index(i).SetInt(int64(index))
+// hasFormData reports whether the form data contains a field, including the
+// fields of nested structs and slice rows
+func hasFormData(values map[string][]string, name, sep string) bool {
+	if _, exists := values[name]; exists {
+		return true
+	}
+	return hasFieldsWithPrefix(values, name+sep) || hasFieldsWithPrefix(values, name+"[")
+}
+
+// hasFieldsWithPrefix reports whether the form data contains a field whose
+// name starts with prefix
+func hasFieldsWithPrefix(values map[string][]string, prefix string) bool {
+	for name := range values {
+		if strings.HasPrefix(name, prefix) {
+			return true
 		}
-		fieldVal.Set(sliceVal)
-	} else {
-		// Single select: bind int
-		index, err := strconv.Atoi(formValues[0])
+	}
+	return false
+}
+
+var errColor = errors.New("expected color as #rrggbb")
+
+// isHexColor reports whether s is a color in the #rrggbb format submitted by
+// color inputs
+func isHexColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeNewlines converts CRLF and lone CR line breaks to LF
+func normalizeNewlines(s string) string {
+	if !strings.Contains(s, "\r") {
+		return s
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+// bindMultiValueField binds form data to a Chosen field by index or key.
+// Empty values bind as no choice if the field can hold none, which the
+// required attribute rejects.
+func bindMultiValueField(values map[string][]string, fieldVal reflect.Value, pair ChoicesChosenPair, config FieldConfig) *FieldError {
+	_, required := config.Attributes["required"]
+	formValues, exists := values[config.Name]
+	if !exists || len(formValues) == 0 {
+		// No form data, leave field unchanged unless a choice is missing
+		if chosen, _ := pair.chosenValues(); required && len(chosen) == 0 {
+			return choiceError(pair, config, "", errChoiceRequired)
+		}
+		return nil
+	}
+
+	options, err := pair.optionValues()
+	if err != nil {
+		return choiceError(pair, config, formValues[0], err)
+	}
+
+	elemType := fieldVal.Type()
+	if pair.IsMultiSelect || pair.pointer {
+		elemType = elemType.Elem()
+	}
+	if !pair.IsMultiSelect {
+		formValues = formValues[:1]
+	}
+	chosen := make([]reflect.Value, 0, len(formValues))
+	for _, formValue := range formValues {
+		if formValue == "" && pair.optional() {
+			continue
+		}
+		index, err := pair.choiceIndex(options, formValue)
 		if err != nil {
-			return fmt.Errorf("vee: invalid index '%s' for single-select field '%s'", formValues[0], config.Name)
+			return choiceError(pair, config, formValue, err)
 		}
-		// Validate index is in range
-		if index < 0 || index >= pair.ChoicesValue.Len() {
-			return fmt.Errorf("vee: index %d out of range for %d choices in field '%s'", index, pair.ChoicesValue.Len(), config.Name)
+		if pair.choice(index).Disabled {
+			return choiceError(pair, config, formValue, fmt.Errorf("choice '%s' is disabled", formValue))
 		}
-		fieldVal.SetInt(int64(index))
+		chosen = append(chosen, pair.chosenFor(elemType, options, index))
+	}
+
+	if required && len(chosen) == 0 {
+		return choiceError(pair, config, formValues[0], errChoiceRequired)
+	}
+
+	switch {
+	case pair.IsMultiSelect:
+		// Multi-select: bind all chosen
+		sliceVal := reflect.MakeSlice(fieldVal.Type(), len(chosen), len(chosen))
+		for i, v := range chosen {
+			sliceVal.Index(i).Set(v)
+		}
+		fieldVal.Set(sliceVal)
+	case len(chosen) == 0:
+		fieldVal.SetZero()
+	default:
+		settableValue(fieldVal, pair.pointer).Set(chosen[0])
 	}
 
 	return nil
 }
+
+// bindHiddenField binds form data to a hidden field with the hidden value
+// codec. Absent fields are left unchanged and empty values set pointers to
+// nil. Errors leave the field unchanged.
+func bindHiddenField(values map[string][]string, fieldVal reflect.Value, fs *fieldSchema, config FieldConfig) *FieldError {
+	formValues, exists := values[config.Name]
+	if !exists || len(formValues) == 0 {
+		return nil
+	}
+
+	if fs.kind == kindSlice {
+		// The presence input comes first, so that an empty slice submits
+		// an empty value
+		if formValues[0] == "" {
+			formValues = formValues[1:]
+		}
+		sliceVal := reflect.MakeSlice(fieldVal.Type(), len(formValues), len(formValues))
+		for i, formValue := range formValues {
+			elem, kind, err := parseHidden(fs.elemKind, formValue, fs.elem)
+			if err != nil {
+				return &FieldError{Name: config.Name, Value: formValue, Kind: kind, Err: err}
+			}
+			sliceVal.Index(i).Set(elem)
+		}
+		fieldVal.Set(sliceVal)
+		return nil
+	}
+
+	formValue := formValues[0]
+	if fs.pointer && formValue == "" {
+		fieldVal.SetZero()
+		return nil
+	}
+	valueType := fs.field.Type
+	if fs.pointer {
+		valueType = valueType.Elem()
+	}
+	value, kind, err := parseHidden(fs.kind, formValue, valueType)
+	if err != nil {
+		return &FieldError{Name: config.Name, Value: formValue, Kind: kind, Err: err}
+	}
+	settableValue(fieldVal, fs.pointer).Set(value)
+	return nil
+}
+
+// choiceError creates a FieldError for an invalid Chosen form value
+func choiceError(pair ChoicesChosenPair, config FieldConfig, formValue string, err error) *FieldError {
+	return &FieldError{
+		Field: pair.ChosenField.Name,
+		Name:  config.Name,
+		Value: formValue,
+		Kind:  KindChoice,
+		Err:   err,
+	}
+}
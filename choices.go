@@ -0,0 +1,475 @@
+package vee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Choice describes an option of a select, radio or checkbox group in detail.
+// Use it as the element type of a Choices field, or implement ChoiceItem.
+type Choice struct {
+	Value       string // Stable key submitted for the option
+	Label       string // Option text, defaults to Value
+	Description string // Help text of the option
+	Disabled    bool   // Whether the option cannot be chosen
+	Group       string // Consecutive options of the same group are rendered as an optgroup or fieldset
+}
+
+// Choice implements ChoiceItem.
+func (c Choice) Choice() Choice {
+	return c
+}
+
+// ChoiceItem is implemented by choices that describe their own option.
+type ChoiceItem interface {
+	Choice() Choice
+}
+
+// ChoicesProvider supplies the choices of Choices fields when rendering and
+// binding, so that options loaded per request (from a database, for the
+// current user) need not be set on the struct beforehand. Providers are
+// registered per field with RegisterChoices, or implemented by the struct
+// for all of its Choices fields.
+type ChoicesProvider interface {
+	// Choices returns the choices of the Choices field named field, a slice
+	// assignable to the field. A nil result keeps the choices of the field.
+	// ctx is the context of the RenderOption or BindOption.
+	Choices(ctx context.Context, field string) (any, error)
+}
+
+// ChoicesFunc adapts a function to a ChoicesProvider.
+type ChoicesFunc func(ctx context.Context, field string) (any, error)
+
+// Choices implements ChoicesProvider.
+func (fn ChoicesFunc) Choices(ctx context.Context, field string) (any, error) {
+	return fn(ctx, field)
+}
+
+// DependentChoicesProvider supplies the choices of Choices fields with a
+// dependsOn attribute, which depend on the choice of another field, such as
+// the regions of the chosen country.
+type DependentChoicesProvider interface {
+	// DependentChoices returns the choices of the Choices field named field
+	// for parent, the chosen element of the Choices field it depends on, or
+	// nil if nothing is chosen. A nil result keeps the choices of the field.
+	DependentChoices(ctx context.Context, field string, parent any) (any, error)
+}
+
+// DependentChoicesFunc adapts a function to a DependentChoicesProvider.
+type DependentChoicesFunc func(ctx context.Context, field string, parent any) (any, error)
+
+// DependentChoices implements DependentChoicesProvider.
+func (fn DependentChoicesFunc) DependentChoices(ctx context.Context, field string, parent any) (any, error) {
+	return fn(ctx, field, parent)
+}
+
+// choicesKey identifies a Choices field of a struct type
+type choicesKey struct {
+	typ   reflect.Type
+	field string
+}
+
+// choicesProviders maps choicesKey to ChoicesProvider or
+// DependentChoicesProvider
+var choicesProviders sync.Map
+
+var (
+	choicesProviderType          = reflect.TypeFor[ChoicesProvider]()
+	dependentChoicesProviderType = reflect.TypeFor[DependentChoicesProvider]()
+)
+
+// RegisterChoices registers the provider of the Choices field named field of
+// the struct type typ, replacing any previous registration. Registered
+// providers take precedence over a ChoicesProvider implemented by the struct.
+func RegisterChoices(typ reflect.Type, field string, provider ChoicesProvider) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	choicesProviders.Store(choicesKey{typ, field}, provider)
+}
+
+// RegisterDependentChoices registers the provider of the Choices field named
+// field of the struct type typ, which has a dependsOn attribute, replacing
+// any previous registration.
+func RegisterDependentChoices(typ reflect.Type, field string, provider DependentChoicesProvider) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	choicesProviders.Store(choicesKey{typ, field}, provider)
+}
+
+// choicesFuncFor returns the function supplying the choices of the Choices
+// field of a struct value for the choice of its parent, or nil. Structs
+// supply the choices of dependent fields with their DependentChoices method
+// if they have one.
+func choicesFuncFor(val reflect.Value, field string, dependent bool) func(ctx context.Context, parent any) (any, error) {
+	var provider any
+	if registered, ok := choicesProviders.Load(choicesKey{val.Type(), field}); ok {
+		provider = registered
+	} else if implementsEither(val.Type(), dependentChoicesProviderType) && dependent {
+		provider = methodValue(val, dependentChoicesProviderType).Interface().(DependentChoicesProvider)
+	} else if implementsEither(val.Type(), choicesProviderType) {
+		// Wrapped so that structs implementing both use Choices
+		provider = ChoicesFunc(methodValue(val, choicesProviderType).Interface().(ChoicesProvider).Choices)
+	}
+
+	switch provider := provider.(type) {
+	case DependentChoicesProvider:
+		return func(ctx context.Context, parent any) (any, error) {
+			return provider.DependentChoices(ctx, field, parent)
+		}
+	case ChoicesProvider:
+		return func(ctx context.Context, _ any) (any, error) {
+			return provider.Choices(ctx, field)
+		}
+	}
+	return nil
+}
+
+// implementsEither reports whether typ or a pointer to it implements iface
+func implementsEither(typ reflect.Type, iface reflect.Type) bool {
+	return typ.Implements(iface) || reflect.PointerTo(typ).Implements(iface)
+}
+
+// provideChoices replaces the choices of a pair with those of its provider,
+// if it has one. Providers of dependent pairs receive the choice of parent.
+func (pair *ChoicesChosenPair) provideChoices(ctx context.Context, val reflect.Value, parent *ChoicesChosenPair) error {
+	provide := choicesFuncFor(val, pair.ChoicesField.Name, parent != nil)
+	if provide == nil {
+		return nil
+	}
+	var parentChoice any
+	if parent != nil {
+		var err error
+		if parentChoice, err = parent.chosenChoice(); err != nil {
+			return err
+		}
+	}
+	choices, err := provide(ctx, parentChoice)
+	if err != nil {
+		return fmt.Errorf("vee: choices of field '%s': %w", pair.ChoicesField.Name, err)
+	}
+	if choices == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(choices)
+	if !v.Type().AssignableTo(pair.ChoicesField.Type) {
+		return structError(ErrChoicesChosen, pair.ChoicesField.Name, "vee: choices provider of field '%s' returned %s, want %s", pair.ChoicesField.Name, v.Type(), pair.ChoicesField.Type)
+	}
+	pair.ChoicesValue = reflect.New(pair.ChoicesField.Type).Elem()
+	pair.ChoicesValue.Set(v)
+	return nil
+}
+
+// defaultEmptyChoices is the message rendered for a Chosen field without
+// choices unless it has an empty attribute
+const defaultEmptyChoices = "No options available"
+
+var errChoiceRequired = errors.New("a choice is required")
+
+// chosenMode is how a Chosen field refers to its choices
+type chosenMode int
+
+const (
+	chosenIndex   chosenMode = iota // int or []int indices into Choices
+	chosenKey                       // string or []string keys of the choices
+	chosenElement                   // the chosen elements of Choices themselves
+)
+
+var (
+	// choiceValuerType is implemented by choices that supply their own key
+	choiceValuerType = reflect.TypeFor[interface{ Value() string }]()
+
+	choiceItemType = reflect.TypeFor[ChoiceItem]()
+	stringerType   = reflect.TypeFor[fmt.Stringer]()
+)
+
+// choiceKeyFunc returns the function computing the stable key of the elements
+// of a Choices field, or nil if they have none. The key is the field named by
+// the valueField attribute, the Value of a ChoiceItem, the result of a
+// Value() string method, the text encoding of an encoding.TextMarshaler, or
+// the formatted value of strings, numbers and bools, in that order.
+func choiceKeyFunc(choicesField reflect.StructField, config FieldConfig) (func(reflect.Value) (string, error), error) {
+	elem := choicesField.Type.Elem()
+
+	if name, ok := config.Attributes["valueField"]; ok {
+		structType := elem
+		if structType.Kind() == reflect.Ptr {
+			structType = structType.Elem()
+		}
+		var keyField reflect.StructField
+		if structType.Kind() == reflect.Struct {
+			keyField, ok = structType.FieldByName(name)
+		}
+		if !ok || !keyField.IsExported() {
+			return nil, structError(ErrChoicesChosen, choicesField.Name, "vee: valueField '%s' of field '%s' is not a field of %s", name, choicesField.Name, elem)
+		}
+		return func(v reflect.Value) (string, error) {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return "", nil
+				}
+				v = v.Elem()
+			}
+			field, err := v.FieldByIndexErr(keyField.Index)
+			if err != nil {
+				return "", nil
+			}
+			return fmt.Sprint(field.Interface()), nil
+		}, nil
+	}
+
+	implements := func(iface reflect.Type) bool {
+		return elem.Implements(iface) || reflect.PointerTo(elem).Implements(iface)
+	}
+	switch {
+	case implements(choiceItemType):
+		return func(v reflect.Value) (string, error) {
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return "", nil
+			}
+			return methodValue(v, choiceItemType).Interface().(ChoiceItem).Choice().Value, nil
+		}, nil
+	case implements(choiceValuerType):
+		return func(v reflect.Value) (string, error) {
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return "", nil
+			}
+			return methodValue(v, choiceValuerType).Interface().(interface{ Value() string }).Value(), nil
+		}, nil
+	case implements(textMarshalerType):
+		return func(v reflect.Value) (string, error) {
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return "", nil
+			}
+			return marshalText(v)
+		}, nil
+	}
+
+	switch elem.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return func(v reflect.Value) (string, error) {
+			return fmt.Sprint(v.Interface()), nil
+		}, nil
+	}
+	return nil, nil
+}
+
+// methodValue returns v, or a pointer to a copy of v if only pointers to its
+// type implement iface
+func methodValue(v reflect.Value, iface reflect.Type) reflect.Value {
+	if v.Type().Implements(iface) {
+		return v
+	}
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr
+}
+
+// choice returns the option of the i-th choice of a pair. Choices that are
+// not a ChoiceItem are labeled with their String method or their value.
+func (pair ChoicesChosenPair) choice(i int) Choice {
+	v := pair.ChoicesValue.Index(i)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return Choice{}
+	}
+
+	var c Choice
+	switch typ := v.Type(); {
+	case typ.Implements(choiceItemType) || reflect.PointerTo(typ).Implements(choiceItemType):
+		c = methodValue(v, choiceItemType).Interface().(ChoiceItem).Choice()
+	case typ.Implements(stringerType) || reflect.PointerTo(typ).Implements(stringerType):
+		c.Label = methodValue(v, stringerType).Interface().(fmt.Stringer).String()
+	default:
+		c.Label = fmt.Sprint(v.Interface())
+	}
+	if c.Label == "" {
+		c.Label = c.Value
+	}
+	return c
+}
+
+// optionValues returns the submitted values of the choices of a pair: their
+// indices, or their keys. Keys must be unique and not empty, which stands
+// for no choice.
+func (pair ChoicesChosenPair) optionValues() ([]string, error) {
+	values := make([]string, pair.ChoicesValue.Len())
+	for i := range values {
+		if pair.mode == chosenIndex {
+			values[i] = strconv.Itoa(i)
+			continue
+		}
+
+		key, err := pair.key(pair.ChoicesValue.Index(i))
+		switch {
+		case err != nil:
+			return nil, structError(ErrChoicesChosen, pair.ChoicesField.Name, "vee: field '%s' choice %d has no key: %v", pair.ChoicesField.Name, i, err)
+		case key == "":
+			return nil, structError(ErrChoicesChosen, pair.ChoicesField.Name, "vee: field '%s' choice %d has an empty key", pair.ChoicesField.Name, i)
+		case slices.Contains(values[:i], key):
+			return nil, structError(ErrChoicesChosen, pair.ChoicesField.Name, "vee: field '%s' has duplicate choice key '%s'", pair.ChoicesField.Name, key)
+		}
+		values[i] = key
+	}
+	return values, nil
+}
+
+// chosenValues returns the submitted values of the chosen choices of a pair
+// in the order of the Chosen field, leaving out empty keys
+func (pair ChoicesChosenPair) chosenValues() ([]string, error) {
+	var chosen []reflect.Value
+	if pair.IsMultiSelect {
+		for i := range pair.ChosenValue.Len() {
+			chosen = append(chosen, pair.ChosenValue.Index(i))
+		}
+	} else if pair.pointer {
+		if !pair.ChosenValue.IsNil() {
+			chosen = []reflect.Value{pair.ChosenValue.Elem()}
+		}
+	} else {
+		chosen = []reflect.Value{pair.ChosenValue}
+	}
+
+	values := make([]string, 0, len(chosen))
+	for _, v := range chosen {
+		var value string
+		switch pair.mode {
+		case chosenIndex:
+			value = strconv.FormatInt(v.Int(), 10)
+		case chosenKey:
+			value = v.String()
+		case chosenElement:
+			var err error
+			if value, err = pair.key(v); err != nil {
+				return nil, structError(ErrChoicesChosen, pair.ChosenField.Name, "vee: field '%s' has no key: %v", pair.ChosenField.Name, err)
+			}
+		}
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// chosenChoice returns the chosen element of the choices of a single-select
+// pair, or nil if nothing is chosen
+func (pair ChoicesChosenPair) chosenChoice() (any, error) {
+	options, err := pair.optionValues()
+	if err != nil {
+		return nil, err
+	}
+	chosen, err := pair.chosenValues()
+	if err != nil || len(chosen) == 0 {
+		return nil, err
+	}
+	index := slices.Index(options, chosen[0])
+	if index < 0 {
+		return nil, nil
+	}
+	return pair.ChoicesValue.Index(index).Interface(), nil
+}
+
+// optional reports whether the Chosen field of a pair can hold no choice:
+// nil pointers, empty keys and empty slices
+func (pair ChoicesChosenPair) optional() bool {
+	return pair.mode != chosenIndex || pair.pointer || pair.IsMultiSelect
+}
+
+// choiceIndex returns the index of the choice with a submitted value
+func (pair ChoicesChosenPair) choiceIndex(options []string, value string) (int, error) {
+	if pair.mode == chosenIndex {
+		index, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, err
+		}
+		if index < 0 || index >= len(options) {
+			return 0, fmt.Errorf("index %d out of range for %d choices", index, len(options))
+		}
+		return index, nil
+	}
+
+	index := slices.Index(options, value)
+	if index < 0 {
+		return 0, fmt.Errorf("unknown choice '%s'", value)
+	}
+	return index, nil
+}
+
+// chosenFor returns the value of a Chosen element referring to a choice
+func (pair ChoicesChosenPair) chosenFor(typ reflect.Type, options []string, index int) reflect.Value {
+	v := reflect.New(typ).Elem()
+	switch pair.mode {
+	case chosenIndex:
+		v.SetInt(int64(index))
+	case chosenKey:
+		v.SetString(options[index])
+	case chosenElement:
+		v.Set(pair.ChoicesValue.Index(index))
+	}
+	return v
+}
+
+// ChoicesHandler returns an HTTP handler that writes the control of a field
+// of the struct type typ, typically a Chosen field whose choices depend on
+// another, for the submitted form. Client code calls it when the parent
+// changes to replace the control with the options for the new choice. The
+// form is bound with BindRequest, so dependent fields get the choices of
+// their providers for the submitted parent, and the field path is read from
+// the field form value, e.g. ?field=RegionChosen&country_chosen=CH.
+//
+// Only the choices of the field and those it depends on are validated, so
+// other Choices fields need not be provided. Invalid values of the form leave
+// their fields unset. Other errors are logged with slog and answered with
+// status 500.
+func ChoicesHandler(typ reflect.Type, opts ...RenderOption) http.Handler {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		field := r.Form.Get("field")
+
+		v := reflect.New(typ).Interface()
+		var bindErrs BindErrors
+		if err := BindRequest(r, v, BindOption{choicesField: field}); err != nil && !errors.As(err, &bindErrs) {
+			choicesHandlerError(w, r, field, err)
+			return
+		}
+
+		opts := append([]RenderOption{ContextOption(r.Context())}, opts...)
+		opts = append(opts, RenderOption{choicesField: field})
+		html, err := RenderInput(v, field, opts...)
+		switch {
+		case errors.Is(err, ErrFieldNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			choicesHandlerError(w, r, field, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, html)
+	})
+}
+
+// choicesHandlerError logs an error of ChoicesHandler, which may come from a
+// provider, and answers with status 500 without exposing it
+func choicesHandlerError(w http.ResponseWriter, r *http.Request, field string, err error) {
+	slog.ErrorContext(r.Context(), "vee: rendering choices failed", "field", field, "err", err)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
@@ -0,0 +1,871 @@
+package vee
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type planCode string
+
+func (p planCode) Value() string { return strings.ToLower(string(p)) }
+
+type priority int
+
+func (p *priority) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[*p]), nil
+}
+
+type country struct {
+	Code string
+	Name string
+}
+
+func TestChoiceKeys(t *testing.T) {
+	type Form struct {
+		ColorChoices []string
+		ColorChosen  string
+		PlanChoices  []planCode
+		PlanChosen   []planCode `vee:"type:'checkbox'"`
+	}
+
+	got, err := Render(Form{
+		ColorChoices: []string{"Red", "Blue"},
+		ColorChosen:  "Blue",
+		PlanChoices:  []planCode{"Free", "Pro", "Team"},
+		PlanChosen:   []planCode{"Team", "Free"},
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `<form method="POST">
+<label for="color_chosen">Color Chosen</label>
+<select name="color_chosen" id="color_chosen">
+<option value="Red">Red</option>
+<option value="Blue" selected>Blue</option>
+</select>
+<fieldset><legend>Plan Chosen</legend>
+<input type="checkbox" name="plan_chosen" value="free" checked id="plan_chosen_0"><label for="plan_chosen_0">Free</label>
+<input type="checkbox" name="plan_chosen" value="pro" id="plan_chosen_1"><label for="plan_chosen_1">Pro</label>
+<input type="checkbox" name="plan_chosen" value="team" checked id="plan_chosen_2"><label for="plan_chosen_2">Team</label>
+</fieldset>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// Keys bind to the same choices after the choices were reordered
+	form := Form{
+		ColorChoices: []string{"Green", "Blue", "Red"},
+		PlanChoices:  []planCode{"Team", "Pro", "Free"},
+	}
+	err = Bind(map[string][]string{"color_chosen": {"Red"}, "plan_chosen": {"pro", "free"}}, &form)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if form.ColorChosen != "Red" || len(form.PlanChosen) != 2 || form.PlanChosen[0] != "Pro" || form.PlanChosen[1] != "Free" {
+		t.Errorf("Bind() = %+v, want Red and Pro, Free", form)
+	}
+
+	// An empty key is no choice
+	if err := Bind(map[string][]string{"color_chosen": {""}}, &form); err != nil || form.ColorChosen != "" {
+		t.Errorf("Bind() = (%v, %q), want no choice", err, form.ColorChosen)
+	}
+}
+
+func TestChoiceKeySources(t *testing.T) {
+	type Form struct {
+		PriorityChoices []priority
+		PriorityChosen  string
+		CountryChoices  []country `vee:"valueField:'Code'"`
+		CountryChosen   country
+		OfficeChoices   []*country `vee:"valueField:'Code'"`
+		OfficeChosen    *country
+	}
+
+	ch, de := &country{"CH", "Switzerland"}, &country{"DE", "Germany"}
+	form := Form{
+		PriorityChoices: []priority{0, 1},
+		PriorityChosen:  "high",
+		CountryChoices:  []country{*ch, *de},
+		OfficeChoices:   []*country{ch, de},
+		OfficeChosen:    de,
+	}
+	got, err := Render(form)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, option := range []string{
+		`<option value="low">`, `<option value="high" selected>`,
+		`<option value="CH">`, `<option value="DE" selected>`,
+	} {
+		if !strings.Contains(got, option) {
+			t.Errorf("Render() = %s, want %s", got, option)
+		}
+	}
+
+	err = Bind(map[string][]string{"priority_chosen": {"low"}, "office_chosen": {"CH"}}, &form)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if form.PriorityChosen != "low" || form.OfficeChosen != ch {
+		t.Errorf("Bind() = %+v, want low and the CH office", form)
+	}
+}
+
+func TestChoiceKeyErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name: "no key",
+			input: struct {
+				CountryChoices []country
+				CountryChosen  string
+			}{CountryChoices: []country{{"CH", "Switzerland"}}},
+			want: "choices of field 'CountryChoices' need a key for 'CountryChosen'",
+		},
+		{
+			name: "unknown value field",
+			input: struct {
+				CountryChoices []country `vee:"valueField:'ISO'"`
+				CountryChosen  string
+			}{CountryChoices: []country{{"CH", "Switzerland"}}},
+			want: "valueField 'ISO' of field 'CountryChoices' is not a field of vee.country",
+		},
+		{
+			name: "duplicate keys",
+			input: struct {
+				PlanChoices []planCode
+				PlanChosen  string
+			}{PlanChoices: []planCode{"Pro", "PRO"}},
+			want: "field 'PlanChoices' has duplicate choice key 'pro'",
+		},
+		{
+			name: "empty key",
+			input: struct {
+				ColorChoices []string
+				ColorChosen  string
+			}{ColorChoices: []string{"Red", ""}},
+			want: "field 'ColorChoices' choice 1 has an empty key",
+		},
+		{
+			name: "unknown chosen key",
+			input: struct {
+				ColorChoices []string
+				ColorChosen  []string
+			}{ColorChoices: []string{"Red"}, ColorChosen: []string{"Red", "Blue"}},
+			want: "field 'ColorChosen' choice 'Blue' is not among its choices",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render(tt.input)
+			if !errors.Is(err, ErrChoicesChosen) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Render() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	// Unknown submitted keys are binding errors
+	form := struct {
+		ColorChoices []string
+		ColorChosen  string
+	}{ColorChoices: []string{"Red"}, ColorChosen: "Red"}
+	err := Bind(map[string][]string{"color_chosen": {"Blue"}}, &form)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Kind != KindChoice || fieldErr.Value != "Blue" || form.ColorChosen != "Red" {
+		t.Errorf("Bind() = (%v, %q), want choice FieldError", err, form.ColorChosen)
+	}
+	if want := "cannot parse 'Blue' as choice for field 'color_chosen'"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Bind() error = %v, want %q", err, want)
+	}
+}
+
+func TestHiddenChoiceKeys(t *testing.T) {
+	type Form struct {
+		PlanChoices []planCode
+		PlanChosen  planCode `vee:"hidden"`
+		TagChoices  []string
+		TagChosen   []string `vee:"hidden"`
+	}
+	input := Form{
+		PlanChoices: []planCode{"Free", "Pro"},
+		PlanChosen:  "Pro",
+		TagChoices:  []string{"a", "b", "c"},
+		TagChosen:   []string{"c", "a"},
+	}
+	out, err := Render(input)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	got := Form{PlanChoices: input.PlanChoices, TagChoices: input.TagChoices}
+	if err := Bind(hiddenInputs(t, out), &got); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if got.PlanChosen != "Pro" || strings.Join(got.TagChosen, ",") != "c,a" {
+		t.Errorf("Bind(Render()) = %+v, want %+v", got, input)
+	}
+}
+
+type status int
+
+func (s status) String() string { return [...]string{"Draft", "Published"}[s] }
+
+type product struct {
+	SKU     string
+	Name    string
+	InStock bool
+}
+
+func (p product) Choice() Choice {
+	return Choice{Value: p.SKU, Label: p.Name, Disabled: !p.InStock}
+}
+
+func TestChoiceItems(t *testing.T) {
+	plans := []Choice{
+		{Value: "free", Label: "Free", Group: "Personal"},
+		{Value: "pro", Label: "Pro", Description: "For professionals", Group: "Personal"},
+		{Value: "team", Label: "Team", Disabled: true, Group: "Business"},
+		{Value: "custom"},
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name: "select",
+			input: struct {
+				PlanChoices []Choice
+				PlanChosen  string
+			}{PlanChoices: plans, PlanChosen: "pro"},
+			want: `<form method="POST">
+<label for="plan_chosen">Plan Chosen</label>
+<select name="plan_chosen" id="plan_chosen">
+<optgroup label="Personal">
+<option value="free">Free</option>
+<option value="pro" title="For professionals" selected>Pro</option>
+</optgroup>
+<optgroup label="Business">
+<option value="team" disabled>Team</option>
+</optgroup>
+<option value="custom">custom</option>
+</select>
+</form>
+`,
+		},
+		{
+			name: "radio",
+			input: struct {
+				PlanChoices []Choice
+				PlanChosen  Choice `vee:"type:'radio'"`
+			}{PlanChoices: plans, PlanChosen: plans[0]},
+			want: `<form method="POST">
+<fieldset><legend>Plan Chosen</legend>
+<fieldset><legend>Personal</legend>
+<input type="radio" name="plan_chosen" value="free" checked id="plan_chosen_0"><label for="plan_chosen_0">Free</label>
+<input type="radio" name="plan_chosen" value="pro" id="plan_chosen_1"><label for="plan_chosen_1">Pro <small>For professionals</small></label>
+</fieldset>
+<fieldset><legend>Business</legend>
+<input type="radio" name="plan_chosen" value="team" id="plan_chosen_2" disabled><label for="plan_chosen_2">Team</label>
+</fieldset>
+<input type="radio" name="plan_chosen" value="custom" id="plan_chosen_3"><label for="plan_chosen_3">custom</label>
+</fieldset>
+</form>
+`,
+		},
+		{
+			name: "checkbox with items and indices",
+			input: struct {
+				ProductChoices []product
+				ProductChosen  []int `vee:"type:'checkbox',nolabel"`
+			}{
+				ProductChoices: []product{{"A1", "Lamp", true}, {"B2", "Desk", false}},
+				ProductChosen:  []int{0},
+			},
+			want: `<form method="POST">
+<input type="checkbox" name="product_chosen" value="0" checked id="product_chosen_0"><label for="product_chosen_0">Lamp</label>
+<input type="checkbox" name="product_chosen" value="1" id="product_chosen_1" disabled><label for="product_chosen_1">Desk</label>
+</form>
+`,
+		},
+		{
+			name: "stringer labels",
+			input: struct {
+				StatusChoices []status
+				StatusChosen  int
+			}{StatusChoices: []status{0, 1}, StatusChosen: 1},
+			want: `<form method="POST">
+<label for="status_chosen">Status Chosen</label>
+<select name="status_chosen" id="status_chosen">
+<option value="0">Draft</option>
+<option value="1" selected>Published</option>
+</select>
+</form>
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.input)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChoiceItemBinding(t *testing.T) {
+	type Order struct {
+		ProductChoices []product
+		ProductChosen  []string
+	}
+	order := Order{ProductChoices: []product{{"A1", "Lamp", true}, {"B2", "Desk", false}}}
+
+	if err := Bind(map[string][]string{"product_chosen": {"A1"}}, &order); err != nil || len(order.ProductChosen) != 1 || order.ProductChosen[0] != "A1" {
+		t.Errorf("Bind() = (%v, %v), want A1", err, order.ProductChosen)
+	}
+
+	// Disabled choices cannot be chosen
+	err := Bind(map[string][]string{"product_chosen": {"A1", "B2"}}, &order)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Kind != KindChoice || fieldErr.Value != "B2" || !strings.Contains(err.Error(), "choice 'B2' is disabled") {
+		t.Errorf("Bind() error = %v, want disabled choice FieldError", err)
+	}
+	if len(order.ProductChosen) != 1 {
+		t.Errorf("Bind() = %v, want field unchanged", order.ProductChosen)
+	}
+}
+
+func TestNoChoice(t *testing.T) {
+	type Form struct {
+		SizeChoices  []string
+		SizeChosen   *int `vee:"placeholder:'Pick a size',required"`
+		ColorChoices []string
+		ColorChosen  *string `vee:"type:'radio'"`
+	}
+
+	got, err := Render(Form{SizeChoices: []string{"S", "M"}, ColorChoices: []string{"Red"}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `<form method="POST">
+<label for="size_chosen">Size Chosen</label>
+<select name="size_chosen" id="size_chosen" required>
+<option value="" selected>Pick a size</option>
+<option value="0">S</option>
+<option value="1">M</option>
+</select>
+<fieldset><legend>Color Chosen</legend>
+<input type="radio" name="color_chosen" value="Red" id="color_chosen_0"><label for="color_chosen_0">Red</label>
+</fieldset>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	size, color := 1, "Red"
+	form := Form{SizeChoices: []string{"S", "M"}, ColorChoices: []string{"Red"}}
+	if err := Bind(map[string][]string{"size_chosen": {"1"}, "color_chosen": {"Red"}}, &form); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if form.SizeChosen == nil || *form.SizeChosen != size || form.ColorChosen == nil || *form.ColorChosen != color {
+		t.Errorf("Bind() = %+v, want M and Red", form)
+	}
+
+	// An empty value is no choice
+	if err := Bind(map[string][]string{"color_chosen": {""}}, &form); err != nil || form.ColorChosen != nil {
+		t.Errorf("Bind() = (%v, %v), want no color", err, form.ColorChosen)
+	}
+
+	// Required fields must have a choice
+	err = Bind(map[string][]string{"size_chosen": {""}}, &form)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Kind != KindChoice || fieldErr.Message() != "Must choose an option" {
+		t.Errorf("Bind() error = %v, want required choice FieldError", err)
+	}
+	if form.SizeChosen == nil || *form.SizeChosen != size {
+		t.Errorf("Bind() = %v, want field unchanged", form.SizeChosen)
+	}
+}
+
+func TestRequiredGroups(t *testing.T) {
+	type Form struct {
+		TagChoices  []string
+		TagChosen   []string `vee:"type:'checkbox',required"`
+		SizeChoices []string
+		SizeChosen  *int `vee:"type:'radio',required"`
+	}
+
+	form := Form{TagChoices: []string{"New", "Sale"}, SizeChoices: []string{"S"}}
+	got, err := Render(form)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `<form method="POST">
+<fieldset><legend>Tag Chosen</legend>
+<input type="checkbox" name="tag_chosen" value="New" id="tag_chosen_0"><label for="tag_chosen_0">New</label>
+<input type="checkbox" name="tag_chosen" value="Sale" id="tag_chosen_1"><label for="tag_chosen_1">Sale</label>
+</fieldset>
+<fieldset><legend>Size Chosen</legend>
+<input type="radio" name="size_chosen" value="0" id="size_chosen_0" required><label for="size_chosen_0">S</label>
+</fieldset>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// The checkbox group is still required by Bind
+	err = Bind(map[string][]string{"size_chosen": {"0"}}, &form)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "TagChosen" || fieldErr.Kind != KindChoice {
+		t.Errorf("Bind() error = %v, want required choice FieldError for TagChosen", err)
+	}
+}
+
+func TestEmptyChoices(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name: "select",
+			input: struct {
+				SizeChoices []string
+				SizeChosen  *int
+			}{},
+			want: `<form method="POST">
+<label for="size_chosen">Size Chosen</label>
+<select name="size_chosen" id="size_chosen">
+<option value="" disabled selected>No options available</option>
+</select>
+</form>
+`,
+		},
+		{
+			name: "checkbox with message",
+			input: struct {
+				TagChoices []string
+				TagChosen  []string `vee:"type:'checkbox',empty:'No tags yet'"`
+			}{},
+			want: `<form method="POST">
+<fieldset><legend>Tag Chosen</legend>
+<p>No tags yet</p>
+</fieldset>
+</form>
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.input)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Single selects of an index must have choices
+	_, err := Render(struct {
+		SizeChoices []string
+		SizeChosen  int
+	}{})
+	if !errors.Is(err, ErrChoicesChosen) {
+		t.Errorf("Render() error = %v, want ErrChoicesChosen", err)
+	}
+}
+
+type userKey struct{}
+
+// projectForm provides the projects of the user in its context
+type projectForm struct {
+	ProjectChoices []string
+	ProjectChosen  string
+	LabelChoices   []string
+	LabelChosen    []int `vee:"type:'checkbox'"`
+}
+
+func (projectForm) Choices(ctx context.Context, field string) (any, error) {
+	switch field {
+	case "ProjectChoices":
+		if ctx.Value(userKey{}) == "ann" {
+			return []string{"Apollo", "Gemini"}, nil
+		}
+		return []string{"Mercury"}, nil
+	}
+	return nil, nil
+}
+
+func TestChoicesProvider(t *testing.T) {
+	ctx := context.WithValue(context.Background(), userKey{}, "ann")
+	got, err := Render(projectForm{LabelChoices: []string{"Urgent"}}, ContextOption(ctx))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `<form method="POST">
+<label for="project_chosen">Project Chosen</label>
+<select name="project_chosen" id="project_chosen">
+<option value="Apollo">Apollo</option>
+<option value="Gemini">Gemini</option>
+</select>
+<fieldset><legend>Label Chosen</legend>
+<input type="checkbox" name="label_chosen" value="0" id="label_chosen_0"><label for="label_chosen_0">Urgent</label>
+</fieldset>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// Binding validates against the provided choices and stores them
+	var form projectForm
+	if err := Bind(map[string][]string{"project_chosen": {"Gemini"}}, &form, BindContextOption(ctx)); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if form.ProjectChosen != "Gemini" || len(form.ProjectChoices) != 2 {
+		t.Errorf("Bind() = %+v, want Gemini and the provided choices", form)
+	}
+
+	// BindRequest passes the request context
+	req := httptest.NewRequest("POST", "/", strings.NewReader(url.Values{"project_chosen": {"Gemini"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	err = BindRequest(req, &projectForm{})
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Kind != KindChoice || fieldErr.Value != "Gemini" {
+		t.Errorf("BindRequest() error = %v, want choice FieldError", err)
+	}
+	if err := BindRequest(req.WithContext(ctx), &projectForm{}); err != nil {
+		t.Errorf("BindRequest() error = %v", err)
+	}
+}
+
+func TestRegisterChoices(t *testing.T) {
+	type Form struct {
+		ProjectChoices []string
+		ProjectChosen  string
+	}
+	errUnavailable := errors.New("unavailable")
+	var provided any
+	RegisterChoices(reflect.TypeFor[*Form](), "ProjectChoices", ChoicesFunc(func(ctx context.Context, field string) (any, error) {
+		if provided == nil {
+			return nil, errUnavailable
+		}
+		return provided, nil
+	}))
+
+	provided = []string{"Skylab"}
+	got, err := Render(Form{ProjectChoices: []string{"Apollo"}, ProjectChosen: "Skylab"})
+	if err != nil || !strings.Contains(got, `<option value="Skylab" selected>Skylab</option>`) || strings.Contains(got, "Apollo") {
+		t.Errorf("Render() = (%s, %v), want the registered choices", got, err)
+	}
+
+	// Registered providers take precedence over the struct
+	RegisterChoices(reflect.TypeFor[projectForm](), "ProjectChoices", ChoicesFunc(func(ctx context.Context, field string) (any, error) {
+		return []string{"Skylab"}, nil
+	}))
+	defer choicesProviders.Delete(choicesKey{reflect.TypeFor[projectForm](), "ProjectChoices"})
+	if got, err := Render(projectForm{}); err != nil || !strings.Contains(got, "Skylab") {
+		t.Errorf("Render() = (%s, %v), want the registered choices", got, err)
+	}
+
+	tests := []struct {
+		name     string
+		provided any
+		want     error
+	}{
+		{"provider error", nil, errUnavailable},
+		{"wrong type", []int{1}, ErrChoicesChosen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provided = tt.provided
+			if _, err := Render(Form{}); !errors.Is(err, tt.want) {
+				t.Errorf("Render() error = %v, want %v", err, tt.want)
+			}
+			if err := Bind(map[string][]string{}, &Form{}); !errors.Is(err, tt.want) {
+				t.Errorf("Bind() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// addressForm provides regions for the chosen country and cities for the
+// chosen region
+type addressForm struct {
+	CountryChoices []string
+	CountryChosen  string
+	RegionChoices  []string `vee:"dependsOn:'Country'"`
+	RegionChosen   string
+	CityChoices    []string `vee:"dependsOn:'Region'"`
+	CityChosen     []string `vee:"type:'checkbox'"`
+}
+
+func (addressForm) Choices(ctx context.Context, field string) (any, error) {
+	return []string{"CH", "DE"}, nil
+}
+
+func (addressForm) DependentChoices(ctx context.Context, field string, parent any) (any, error) {
+	options := map[any][]string{
+		"CH":     {"Bern", "Zurich"},
+		"DE":     {"Bavaria"},
+		"Zurich": {"Winterthur", "Uster"},
+	}
+	return options[parent], nil
+}
+
+func TestDependentChoices(t *testing.T) {
+	got, err := Render(addressForm{CountryChosen: "CH", RegionChosen: "Zurich", CityChosen: []string{"Uster"}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `<form method="POST">
+<label for="country_chosen">Country Chosen</label>
+<select name="country_chosen" id="country_chosen">
+<option value="CH" selected>CH</option>
+<option value="DE">DE</option>
+</select>
+<label for="region_chosen">Region Chosen</label>
+<select name="region_chosen" data-depends-on="country_chosen" id="region_chosen">
+<option value="Bern">Bern</option>
+<option value="Zurich" selected>Zurich</option>
+</select>
+<fieldset data-depends-on="region_chosen"><legend>City Chosen</legend>
+<input type="checkbox" name="city_chosen" value="Winterthur" id="city_chosen_0"><label for="city_chosen_0">Winterthur</label>
+<input type="checkbox" name="city_chosen" value="Uster" checked id="city_chosen_1"><label for="city_chosen_1">Uster</label>
+</fieldset>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// Groups without a label keep a fieldset for data-depends-on
+	got, err = RenderInput(struct {
+		CountryChoices []string
+		CountryChosen  string
+		CityChoices    []string `vee:"dependsOn:'Country'"`
+		CityChosen     []string `vee:"type:'checkbox',nolabel"`
+	}{CountryChoices: []string{"CH"}, CountryChosen: "CH", CityChoices: []string{"Bern"}}, "CityChosen")
+	want = `<fieldset data-depends-on="country_chosen">
+<input type="checkbox" name="city_chosen" value="Bern" id="city_chosen_0"><label for="city_chosen_0">Bern</label>
+</fieldset>
+`
+	if err != nil || got != want {
+		t.Errorf("RenderInput() = (%q, %v), want %q", got, err, want)
+	}
+
+	// Dependent choices are bound against the choices for the submitted parent
+	var form addressForm
+	err = Bind(map[string][]string{"country_chosen": {"CH"}, "region_chosen": {"Zurich"}, "city_chosen": {"Uster"}}, &form)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if form.RegionChosen != "Zurich" || len(form.CityChoices) != 2 || strings.Join(form.CityChosen, ",") != "Uster" {
+		t.Errorf("Bind() = %+v, want Zurich and Uster", form)
+	}
+
+	tests := []struct {
+		name   string
+		form   addressForm
+		values map[string][]string
+		value  string
+	}{
+		{"child of another parent", addressForm{}, map[string][]string{"country_chosen": {"DE"}, "region_chosen": {"Zurich"}}, "Zurich"},
+		{"kept child of the previous parent", addressForm{CountryChosen: "CH", RegionChosen: "Bern"}, map[string][]string{"country_chosen": {"DE"}}, "Bern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bind(tt.values, &tt.form)
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Field != "RegionChosen" || fieldErr.Kind != KindChoice || fieldErr.Value != tt.value {
+				t.Errorf("Bind() error = %v, want RegionChosen choice FieldError", err)
+			}
+		})
+	}
+
+	// Without a parent choice there are no dependent choices
+	form = addressForm{}
+	if err := Bind(map[string][]string{"region_chosen": {""}}, &form); err != nil || len(form.RegionChoices) != 0 {
+		t.Errorf("Bind() = (%v, %v), want no regions", err, form.RegionChoices)
+	}
+}
+
+func TestRegisterDependentChoices(t *testing.T) {
+	type Form struct {
+		CountryChoices []country `vee:"valueField:'Code'"`
+		CountryChosen  *country
+		OfficeChoices  []string `vee:"dependsOn:'Country'"`
+		OfficeChosen   *int
+	}
+	var parents []any
+	RegisterDependentChoices(reflect.TypeFor[Form](), "OfficeChoices", DependentChoicesFunc(func(ctx context.Context, field string, parent any) (any, error) {
+		parents = append(parents, parent)
+		return []string{"Main office"}, nil
+	}))
+
+	ch := &country{"CH", "Switzerland"}
+	form := Form{CountryChoices: []country{*ch}}
+	if err := Bind(map[string][]string{"country_chosen": {"CH"}, "office_chosen": {"0"}}, &form); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if _, err := Render(Form{CountryChoices: []country{*ch}}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if form.OfficeChosen == nil || *form.OfficeChosen != 0 || len(parents) != 2 || parents[0] != *ch || parents[1] != nil {
+		t.Errorf("Bind() = %+v with parents %v, want the main office of CH and then no parent", form, parents)
+	}
+}
+
+func TestDependentChoicesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name: "unknown parent",
+			input: &struct {
+				RegionChoices []string `vee:"dependsOn:'Country'"`
+				RegionChosen  string
+			}{},
+			want: "field 'RegionChoices' depends on 'Country', which has no Choices/Chosen pair",
+		},
+		{
+			name: "multi-select parent",
+			input: &struct {
+				CountryChoices []string
+				CountryChosen  []string
+				RegionChoices  []string `vee:"dependsOn:'Country'"`
+				RegionChosen   string
+			}{},
+			want: "field 'RegionChoices' cannot depend on multi-select field 'CountryChosen'",
+		},
+		{
+			name: "index child",
+			input: &struct {
+				CountryChoices []string
+				CountryChosen  string
+				RegionChoices  []string `vee:"dependsOn:'Country'"`
+				RegionChosen   int
+			}{},
+			want: "field 'RegionChosen' depends on 'Country' and must be able to hold no choice",
+		},
+		{
+			name: "circular",
+			input: &struct {
+				CountryChoices []string `vee:"dependsOn:'Region'"`
+				CountryChosen  string
+				RegionChoices  []string `vee:"dependsOn:'Country'"`
+				RegionChosen   string
+			}{},
+			want: "field 'CountryChoices' has a circular dependsOn",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bind(map[string][]string{}, tt.input)
+			if !errors.Is(err, ErrChoicesChosen) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Bind() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// shippingForm provides countries and regions, but not its sizes
+type shippingForm struct {
+	CountryChoices []string
+	CountryChosen  string
+	RegionChoices  []string `vee:"dependsOn:'Country'"`
+	RegionChosen   *int
+	SizeChoices    []string
+	SizeChosen     int
+}
+
+func (shippingForm) Choices(ctx context.Context, field string) (any, error) {
+	if field == "CountryChoices" {
+		return []string{"CH", "DE"}, nil
+	}
+	return nil, nil
+}
+
+func (shippingForm) DependentChoices(ctx context.Context, field string, parent any) (any, error) {
+	if parent == "DE" {
+		return nil, errors.New("regions unavailable")
+	}
+	return []string{"Bern", "Zurich"}, nil
+}
+
+func TestChoicesHandlerValidation(t *testing.T) {
+	handler := ChoicesHandler(reflect.TypeFor[shippingForm]())
+
+	// Choices the field does not depend on are not validated
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/choices?field=RegionChosen&country_chosen=CH&region_chosen=1&size_chosen=2", nil))
+	want := `<select name="region_chosen" data-depends-on="country_chosen" id="region_chosen">
+<option value="0">Bern</option>
+<option value="1" selected>Zurich</option>
+</select>
+`
+	if rec.Code != 200 || rec.Body.String() != want {
+		t.Errorf("ServeHTTP() = %d %q, want %q", rec.Code, rec.Body.String(), want)
+	}
+
+	// Provider errors are logged
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/choices?field=RegionChosen&country_chosen=DE", nil))
+	if rec.Code != 500 || strings.Contains(rec.Body.String(), "unavailable") {
+		t.Errorf("ServeHTTP() = %d %q, want 500 without the error", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(logs.String(), "field=RegionChosen") || !strings.Contains(logs.String(), "regions unavailable") {
+		t.Errorf("logged %q, want the provider error", logs.String())
+	}
+}
+
+func TestChoicesHandler(t *testing.T) {
+	handler := ChoicesHandler(reflect.TypeFor[addressForm]())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/choices?field=RegionChosen&country_chosen=DE&region_chosen=Zurich", nil))
+	want := `<select name="region_chosen" data-depends-on="country_chosen" id="region_chosen">
+<option value="Bavaria">Bavaria</option>
+</select>
+`
+	if rec.Code != 200 || rec.Body.String() != want || rec.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("ServeHTTP() = %d %q, want %q", rec.Code, rec.Body.String(), want)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/choices?field=StateChosen", nil))
+	if rec.Code != 404 {
+		t.Errorf("ServeHTTP() = %d, want 404 for an unknown field", rec.Code)
+	}
+}
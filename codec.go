@@ -0,0 +1,67 @@
+package vee
+
+import (
+	"io"
+	"reflect"
+	"sync"
+)
+
+// FieldCodec renders and binds the fields of a type registered with
+// RegisterType. Fields of the type, or of a pointer to it, go through the
+// same label, CSS, help and error handling as the built-in types.
+type FieldCodec interface {
+	// Render fills in the control of f from the field value v, typically
+	// Type, Value, HasValue and Attrs. f already holds the name, id, label,
+	// CSS classes and errors of the field. v is the zero value for nil
+	// pointers. The field is written with Renderer.Input unless the codec
+	// implements ControlWriter.
+	Render(f *Field, v reflect.Value) error
+
+	// Bind parses the values submitted under the field name into the
+	// settable value v. It is only called when values were submitted, and a
+	// returned error is reported as a FieldError for the field.
+	Bind(v reflect.Value, values []string) error
+}
+
+// ControlWriter is implemented by FieldCodecs that write their own control
+// markup, such as an amount input followed by a currency select. Controls
+// submitting several values should share the field name.
+type ControlWriter interface {
+	WriteControl(w io.Writer, f *Field) error
+}
+
+// HiddenCodec is implemented by FieldCodecs whose values span several form
+// values to store them in a single hidden input. Other codecs store the Value
+// set by Render and bind it as a single value. Codecs that implement
+// ControlWriter must implement HiddenCodec to be used in hidden fields.
+type HiddenCodec interface {
+	// FormatHidden formats the field value v for a hidden input
+	FormatHidden(v reflect.Value) (string, error)
+
+	// ParseHidden parses a value formatted by FormatHidden into the
+	// settable value v
+	ParseHidden(v reflect.Value, value string) error
+}
+
+// codecs maps reflect.Type to FieldCodec
+var codecs sync.Map
+
+// RegisterType registers the codec used to render and bind fields of type
+// typ, replacing any previous registration. Registered types take precedence
+// over the built-in handling, including time.Time, text marshaling types
+// and nested structs. Types are best registered before their first use,
+// typically in an init function.
+func RegisterType(typ reflect.Type, codec FieldCodec) {
+	codecs.Store(typ, codec)
+
+	// Compiled schemas may have resolved the type without the codec
+	schemaCache.Clear()
+}
+
+// codecFor returns the codec registered for a type, or nil
+func codecFor(typ reflect.Type) FieldCodec {
+	if codec, ok := codecs.Load(typ); ok {
+		return codec.(FieldCodec)
+	}
+	return nil
+}
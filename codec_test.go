@@ -0,0 +1,251 @@
+package vee
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// codecLatLng renders as a single "lat,lng" text input
+type codecLatLng struct {
+	Lat, Lng float64
+}
+
+type latLngCodec struct{}
+
+func (latLngCodec) Render(f *Field, v reflect.Value) error {
+	p := v.Interface().(codecLatLng)
+	f.Value = fmt.Sprintf("%g,%g", p.Lat, p.Lng)
+	f.HasValue = true
+	f.Attrs = append(f.Attrs, Attr{Name: "pattern", Value: `-?[0-9.]+,-?[0-9.]+`})
+	return nil
+}
+
+func (latLngCodec) Bind(v reflect.Value, values []string) error {
+	lat, lng, ok := strings.Cut(values[0], ",")
+	if !ok {
+		return errors.New("expected lat,lng")
+	}
+	var p codecLatLng
+	var err error
+	if p.Lat, err = strconv.ParseFloat(lat, 64); err != nil {
+		return err
+	}
+	if p.Lng, err = strconv.ParseFloat(lng, 64); err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(p))
+	return nil
+}
+
+// codecMoney renders as an amount input followed by a currency select, both
+// submitted under the field name
+type codecMoney struct {
+	Cents    int64
+	Currency string
+}
+
+type moneyCodec struct{}
+
+func (moneyCodec) Render(f *Field, v reflect.Value) error {
+	m := v.Interface().(codecMoney)
+	f.Value = strconv.FormatFloat(float64(m.Cents)/100, 'f', 2, 64)
+	f.HasValue = true
+	for _, currency := range []string{"EUR", "USD"} {
+		f.Options = append(f.Options, Option{Value: currency, Label: currency, Selected: currency == m.Currency})
+	}
+	return nil
+}
+
+func (moneyCodec) WriteControl(w io.Writer, f *Field) error {
+	if err := (HTMLRenderer{}).Input(w, &Field{Type: "number", Name: f.Name, ID: f.ID, Value: f.Value, HasValue: true, CSS: f.CSS, Attrs: []Attr{{Name: "step", Value: "0.01"}}}); err != nil {
+		return err
+	}
+	return (HTMLRenderer{}).Select(w, &Field{Name: f.Name, ID: f.ID + "_currency", Options: f.Options})
+}
+
+func (moneyCodec) Bind(v reflect.Value, values []string) error {
+	if len(values) != 2 {
+		return errors.New("expected amount and currency")
+	}
+	amount, err := strconv.ParseFloat(values[0], 64)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(codecMoney{Cents: int64(amount * 100), Currency: values[1]}))
+	return nil
+}
+
+func (moneyCodec) FormatHidden(v reflect.Value) (string, error) {
+	m := v.Interface().(codecMoney)
+	return strconv.FormatInt(m.Cents, 10) + " " + m.Currency, nil
+}
+
+func (moneyCodec) ParseHidden(v reflect.Value, value string) error {
+	amount, currency, _ := strings.Cut(value, " ")
+	cents, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(codecMoney{Cents: cents, Currency: currency}))
+	return nil
+}
+
+// codecRange writes a from and a to input without a hidden form
+type codecRange struct {
+	From, To int
+}
+
+type rangeCodec struct{}
+
+func (rangeCodec) Render(f *Field, v reflect.Value) error {
+	r := v.Interface().(codecRange)
+	f.Value = strconv.Itoa(r.From) + "-" + strconv.Itoa(r.To)
+	return nil
+}
+
+func (rangeCodec) WriteControl(w io.Writer, f *Field) error {
+	_, err := io.WriteString(w, f.Value)
+	return err
+}
+
+func (rangeCodec) Bind(v reflect.Value, values []string) error {
+	return nil
+}
+
+func init() {
+	RegisterType(reflect.TypeOf(codecLatLng{}), latLngCodec{})
+	RegisterType(reflect.TypeOf(codecMoney{}), moneyCodec{})
+	RegisterType(reflect.TypeOf(codecRange{}), rangeCodec{})
+}
+
+type codecForm struct {
+	Location codecLatLng `vee:"label:'Pickup'" css:"geo"`
+	Price    codecMoney  `vee:"required"`
+	Fallback *codecLatLng
+}
+
+func TestCodecRendering(t *testing.T) {
+	form := codecForm{
+		Location: codecLatLng{52.5, 13.4},
+		Price:    codecMoney{Cents: 1999, Currency: "USD"},
+	}
+
+	errs := make(FieldErrors)
+	errs.Add("Price", "Too expensive")
+
+	got, err := Render(form, RenderOption{Errors: errs})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<form method="POST">
+<label for="location">Pickup</label>
+<input type="text" name="location" value="52.5,13.4" pattern="-?[0-9.]+,-?[0-9.]+" class="geo" id="location">
+<label for="price">Price</label>
+<input type="number" name="price" value="19.99" step="0.01" class="error" id="price">
+<select name="price" id="price_currency">
+<option value="EUR">EUR</option>
+<option value="USD" selected>USD</option>
+</select>
+<span id="price_error">Too expensive</span>
+<label for="fallback">Fallback</label>
+<input type="text" name="fallback" value="0,0" pattern="-?[0-9.]+,-?[0-9.]+" id="fallback">
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestCodecBinding(t *testing.T) {
+	var form codecForm
+	err := Bind(map[string][]string{
+		"location": {"1.5,-2"},
+		"price":    {"12.50", "EUR"},
+		"fallback": {"3,4"},
+	}, &form)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if form.Location != (codecLatLng{1.5, -2}) || form.Price != (codecMoney{1250, "EUR"}) {
+		t.Errorf("Bind() = %+v, want parsed codec values", form)
+	}
+	if form.Fallback == nil || *form.Fallback != (codecLatLng{3, 4}) {
+		t.Errorf("Bind() Fallback = %v, want 3,4", form.Fallback)
+	}
+
+	form = codecForm{Location: codecLatLng{1, 1}}
+	err = Bind(map[string][]string{"location": {"nowhere"}}, &form)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Location" || fieldErr.Message() != "Invalid value" {
+		t.Fatalf("Bind() error = %v, want FieldError for Location", err)
+	}
+	if form.Location != (codecLatLng{1, 1}) || form.Fallback != nil {
+		t.Errorf("Bind() = %+v, want fields unchanged", form)
+	}
+}
+
+func TestCodecHidden(t *testing.T) {
+	type State struct {
+		Location codecLatLng  `vee:"hidden"`
+		Price    codecMoney   `vee:"hidden"`
+		Prices   []codecMoney `vee:"hidden"`
+		Fallback *codecLatLng `vee:"hidden"`
+	}
+	state := State{
+		Location: codecLatLng{52.5, 13.4},
+		Price:    codecMoney{Cents: 1999, Currency: "USD"},
+		Prices:   []codecMoney{{100, "EUR"}, {250, "USD"}},
+	}
+
+	out, err := Render(state)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{
+		`<input type="hidden" name="location" value="52.5,13.4" id="location">`,
+		`<input type="hidden" name="price" value="1999 USD" id="price">`,
+		`<input type="hidden" name="prices" value="250 USD" id="prices_1">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render() missing %s\nhtml %s", want, out)
+		}
+	}
+
+	got := State{Fallback: &codecLatLng{1, 1}}
+	if err := Bind(hiddenInputs(t, out), &got); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, state) {
+		t.Errorf("Bind(Render()) = %+v, want %+v", got, state)
+	}
+
+	_, err = Render(struct {
+		Span codecRange `vee:"hidden"`
+	}{})
+	if !errors.Is(err, ErrUnsupportedHidden) {
+		t.Errorf("Render() error = %v, want ErrUnsupportedHidden for a ControlWriter without HiddenCodec", err)
+	}
+}
+
+func TestCodecRegistrationClearsSchemas(t *testing.T) {
+	type Point struct {
+		X, Y int
+	}
+	type Form struct {
+		Origin Point
+	}
+
+	if schemaFor(reflect.TypeOf(Form{})).fields[0].kind != kindStruct {
+		t.Fatal("Point should be a nested struct before registration")
+	}
+	RegisterType(reflect.TypeOf(Point{}), latLngCodec{})
+	if fs := schemaFor(reflect.TypeOf(Form{})).fields[0]; fs.kind != kindCodec || fs.codec == nil {
+		t.Errorf("field kind = %v, want kindCodec after registration", fs.kind)
+	}
+}
@@ -0,0 +1,125 @@
+package vee
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// durationUnits are the units of time.Duration fields, in increasing size
+var durationUnits = []struct {
+	name  string
+	label string
+	size  time.Duration
+}{
+	{"ms", "milliseconds", time.Millisecond},
+	{"s", "seconds", time.Second},
+	{"m", "minutes", time.Minute},
+	{"h", "hours", time.Hour},
+	{"d", "days", 24 * time.Hour},
+}
+
+// durationInput describes how the values of a time.Duration field are
+// rendered and parsed. It is compiled once per field from the units,
+// unitselect and format attributes.
+type durationInput struct {
+	unit       string // Unit of the number, or the default unit of the units select
+	selectable bool   // Whether a select lets the user choose the unit
+	text       bool   // Whether values are Go duration strings (1h30m) in a text input
+}
+
+// compileDurationInput returns the duration input of a field
+func compileDurationInput(config FieldConfig) durationInput {
+	if config.Attributes["format"] == "go" {
+		return durationInput{text: true}
+	}
+	in := durationInput{unit: "s"}
+	if _, ok := unitSize(config.Attributes["units"]); ok {
+		in.unit = config.Attributes["units"]
+	}
+	_, in.selectable = config.Attributes["unitselect"]
+	return in
+}
+
+// unitSize returns the size of a duration unit
+func unitSize(unit string) (time.Duration, bool) {
+	for _, u := range durationUnits {
+		if u.name == unit {
+			return u.size, true
+		}
+	}
+	return 0, false
+}
+
+// displayUnit returns the unit a duration is rendered in: the largest unit
+// the duration is a whole number of if the user can select the unit, or else
+// the unit of the field
+func (in durationInput) displayUnit(d time.Duration) string {
+	if in.selectable && d != 0 {
+		for i := len(durationUnits) - 1; i >= 0; i-- {
+			if d%durationUnits[i].size == 0 {
+				return durationUnits[i].name
+			}
+		}
+	}
+	return in.unit
+}
+
+// formatDuration formats a duration as a decimal number of a unit. Durations
+// that are a whole number of units, and all durations below 2^53 nanoseconds
+// (104 days), are formatted without loss.
+func formatDuration(d time.Duration, unit string) string {
+	size, _ := unitSize(unit)
+	if d%size == 0 {
+		return strconv.FormatInt(int64(d/size), 10)
+	}
+	return strconv.FormatFloat(float64(d)/float64(size), 'f', -1, 64)
+}
+
+// parseDuration parses a decimal number of a unit, rounded to the nanosecond
+func parseDuration(value string, unit string) (time.Duration, error) {
+	size, _ := unitSize(unit)
+	n, err := strconv.ParseFloat(value, 64)
+	if err == nil && (math.IsNaN(n) || math.IsInf(n, 0)) {
+		err = &strconv.NumError{Func: "ParseFloat", Num: value, Err: strconv.ErrSyntax}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ns := math.Round(n * float64(size))
+	if ns >= math.MaxInt64 || ns < math.MinInt64 {
+		rangeErr := &RangeError{Max: ns > 0, Err: &strconv.NumError{Func: "ParseFloat", Num: value, Err: strconv.ErrRange}}
+		if rangeErr.Max {
+			rangeErr.Limit = formatDuration(math.MaxInt64, unit)
+		} else {
+			rangeErr.Limit = formatDuration(math.MinInt64, unit)
+		}
+		return 0, rangeErr
+	}
+	return time.Duration(ns), nil
+}
+
+// unitsName returns the form field name of the units select of a duration field
+func unitsName(name string) string {
+	return name + "_units"
+}
+
+// unitsField returns the units select rendered after the number input of a
+// duration field, with the given unit selected
+func unitsField(f *Field, unit string) *Field {
+	units := &Field{
+		StructField: f.StructField,
+		Name:        unitsName(f.Name),
+		ID:          f.ID + "_units",
+		Type:        "select",
+		CSS:         f.CSS,
+		Disabled:    f.Disabled,
+		Attrs:       []Attr{{Name: "aria-label", Value: f.Label + " units"}},
+		control:     controlSelect,
+	}
+	for _, u := range durationUnits {
+		units.Options = append(units.Options, Option{Value: u.name, Label: u.label, Selected: u.name == unit})
+	}
+	return units
+}
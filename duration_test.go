@@ -0,0 +1,185 @@
+package vee
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDurationFractions(t *testing.T) {
+	tests := []struct {
+		units    string
+		duration time.Duration
+		value    string
+	}{
+		{"s", 1500 * time.Millisecond, "1.5"},
+		{"m", 90 * time.Second, "1.5"},
+		{"h", 45 * time.Minute, "0.75"},
+		{"d", 36 * time.Hour, "1.5"},
+		{"ms", 1500 * time.Microsecond, "1.5"},
+		{"s", -2500 * time.Millisecond, "-2.5"},
+		{"m", 20 * time.Second, "0.3333333333333333"},
+		{"s", time.Nanosecond, "0.000000001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.units+" "+tt.duration.String(), func(t *testing.T) {
+			config := FieldConfig{Attributes: map[string]string{"units": tt.units}}
+			in := compileDurationInput(config)
+			if got := formatDuration(tt.duration, in.unit); got != tt.value {
+				t.Errorf("formatDuration() = %q, want %q", got, tt.value)
+			}
+			if got, err := parseDuration(tt.value, in.unit); err != nil || got != tt.duration {
+				t.Errorf("parseDuration(%q) = (%v, %v), want %v", tt.value, got, err, tt.duration)
+			}
+		})
+	}
+
+	type Task struct {
+		Estimate time.Duration `vee:"units:'h'"`
+	}
+	got, err := Render(Task{Estimate: 90 * time.Minute})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(got, `<input type="number" name="estimate" value="1.5" step="any" id="estimate">`) {
+		t.Errorf("Render() = %s, want fractional hours", got)
+	}
+
+	var task Task
+	if err := Bind(map[string][]string{"estimate": {"1.25"}}, &task); err != nil || task.Estimate != 75*time.Minute {
+		t.Errorf("Bind() = (%v, %v), want 1h15m", err, task.Estimate)
+	}
+}
+
+func TestDurationUnitSelect(t *testing.T) {
+	type Job struct {
+		Timeout time.Duration  `vee:"units:'m',unitselect,min:'1'"`
+		Retry   *time.Duration `vee:"unitselect"`
+	}
+
+	got, err := Render(Job{Timeout: 2 * time.Hour})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `<form method="POST">
+<label for="timeout">Timeout</label>
+<input type="number" name="timeout" value="2" step="any" id="timeout">
+<select name="timeout_units" aria-label="Timeout units" id="timeout_units">
+<option value="ms">milliseconds</option>
+<option value="s">seconds</option>
+<option value="m">minutes</option>
+<option value="h" selected>hours</option>
+<option value="d">days</option>
+</select>
+<label for="retry">Retry</label>
+<input type="number" name="retry" step="any" id="retry">
+<select name="retry_units" aria-label="Retry units" id="retry_units">
+<option value="ms">milliseconds</option>
+<option value="s" selected>seconds</option>
+<option value="m">minutes</option>
+<option value="h">hours</option>
+<option value="d">days</option>
+</select>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		name   string
+		values map[string][]string
+		want   time.Duration
+	}{
+		{"selected unit", map[string][]string{"timeout": {"1.5"}, "timeout_units": {"d"}}, 36 * time.Hour},
+		{"field unit without select", map[string][]string{"timeout": {"1.5"}}, 90 * time.Second},
+		{"milliseconds", map[string][]string{"timeout": {"250"}, "timeout_units": {"ms"}}, 250 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var job Job
+			if err := Bind(tt.values, &job); err != nil || job.Timeout != tt.want {
+				t.Errorf("Bind() = (%v, %v), want %v", err, job.Timeout, tt.want)
+			}
+		})
+	}
+
+	var job Job
+	err = Bind(map[string][]string{"timeout": {"1"}, "timeout_units": {"w"}}, &job)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Name != "timeout_units" || fieldErr.Kind != KindDuration || job.Timeout != 0 {
+		t.Errorf("Bind() = (%v, %v), want units FieldError", err, job.Timeout)
+	}
+}
+
+func TestDurationGoFormat(t *testing.T) {
+	type Schedule struct {
+		Interval time.Duration  `vee:"format:'go',placeholder:'1h30m'"`
+		Backoff  *time.Duration `vee:"format:'go'"`
+	}
+
+	got, err := Render(Schedule{Interval: 90*time.Minute + 500*time.Millisecond})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `<form method="POST">
+<label for="interval">Interval</label>
+<input type="text" name="interval" value="1h30m0.5s" id="interval" placeholder="1h30m">
+<label for="backoff">Backoff</label>
+<input type="text" name="backoff" id="backoff">
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	var schedule Schedule
+	err = Bind(map[string][]string{"interval": {"1h30m0.5s"}, "backoff": {"250ms"}}, &schedule)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if schedule.Interval != 90*time.Minute+500*time.Millisecond || schedule.Backoff == nil || *schedule.Backoff != 250*time.Millisecond {
+		t.Errorf("Bind() = %+v, want 1h30m0.5s and 250ms", schedule)
+	}
+
+	err = Bind(map[string][]string{"interval": {"90"}}, &schedule)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Kind != KindDuration || fieldErr.Message() != "Must be a valid duration" {
+		t.Errorf("Bind() error = %v, want duration FieldError", err)
+	}
+}
+
+func TestDurationBindingErrors(t *testing.T) {
+	type Form struct {
+		Timeout time.Duration `vee:"units:'h'"`
+	}
+
+	tests := []struct {
+		value   string
+		message string
+	}{
+		{"soon", "Must be a valid duration"},
+		{"NaN", "Must be a valid duration"},
+		{"3000000", "Must be at most 2562047.7880152157"},
+		{"-3000000", "Must be at least -2562047.7880152157"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			form := Form{Timeout: time.Hour}
+			err := Bind(map[string][]string{"timeout": {tt.value}}, &form)
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Kind != KindDuration {
+				t.Fatalf("Bind() error = %v, want duration FieldError", err)
+			}
+			if msg := fieldErr.Message(); msg != tt.message {
+				t.Errorf("Message() = %q, want %q", msg, tt.message)
+			}
+			if form.Timeout != time.Hour {
+				t.Errorf("Bind() = %v, want field unchanged", form.Timeout)
+			}
+		})
+	}
+}
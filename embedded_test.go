@@ -0,0 +1,198 @@
+package vee
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type embeddedAudit struct {
+	CreatedBy string `validate:"required"`
+	Note      string
+}
+
+type embeddedContact struct {
+	Email string `vee:"type:'email'"`
+	Phone string
+}
+
+type embeddedPrefs struct {
+	ColorChoices []string
+	ColorChosen  int
+}
+
+type embeddedInternal struct {
+	Token string
+}
+
+type embeddedUser struct {
+	Name string
+	embeddedAudit
+	*embeddedContact
+	embeddedPrefs
+}
+
+func TestEmbeddedRendering(t *testing.T) {
+	user := embeddedUser{
+		Name:          "John",
+		embeddedAudit: embeddedAudit{CreatedBy: "admin"},
+		embeddedPrefs: embeddedPrefs{ColorChoices: []string{"Red", "Blue"}, ColorChosen: 1},
+	}
+
+	got, err := Render(user)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<form method="POST">
+<label for="name">Name</label>
+<input type="text" name="name" value="John" id="name">
+<label for="created_by">Created By</label>
+<input type="text" name="created_by" value="admin" id="created_by">
+<label for="note">Note</label>
+<input type="text" name="note" value="" id="note">
+<label for="email">Email</label>
+<input type="email" name="email" value="" id="email">
+<label for="phone">Phone</label>
+<input type="text" name="phone" value="" id="phone">
+<label for="color_chosen">Color Chosen</label>
+<select name="color_chosen" id="color_chosen">
+<option value="0">Red</option>
+<option value="1" selected>Blue</option>
+</select>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestEmbeddedBinding(t *testing.T) {
+	t.Run("promoted fields", func(t *testing.T) {
+		user := embeddedUser{embeddedPrefs: embeddedPrefs{ColorChoices: []string{"Red", "Blue"}}}
+		err := Bind(map[string][]string{
+			"name":         {"John"},
+			"created_by":   {"admin"},
+			"color_chosen": {"1"},
+		}, &user)
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if user.Name != "John" || user.CreatedBy != "admin" || user.ColorChosen != 1 {
+			t.Errorf("Bind() = %+v, want promoted fields bound", user)
+		}
+		if user.embeddedContact != nil {
+			t.Errorf("Bind() embeddedContact = %+v, want nil without submitted fields", user.embeddedContact)
+		}
+	})
+
+	t.Run("allocates embedded pointers", func(t *testing.T) {
+		type Contact struct {
+			Email  string
+			Public bool
+		}
+		type Form struct {
+			*Contact
+		}
+
+		var form Form
+		if err := Bind(map[string][]string{"email": {"a@b.c"}}, &form); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if form.Contact == nil || form.Email != "a@b.c" {
+			t.Errorf("Bind() Contact = %+v, want allocated with Email", form.Contact)
+		}
+	})
+
+	t.Run("unexported embedded pointers are not allocated", func(t *testing.T) {
+		var user embeddedUser
+		user.ColorChoices = []string{"Red"}
+		if err := Bind(map[string][]string{"email": {"a@b.c"}}, &user); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if user.embeddedContact != nil {
+			t.Errorf("Bind() embeddedContact = %+v, want nil", user.embeddedContact)
+		}
+	})
+}
+
+func TestEmbeddedConflicts(t *testing.T) {
+	type A struct {
+		Name  string
+		Title string
+		Code  string
+	}
+	type B struct {
+		Title string
+		Code  string `vee:"$code"`
+	}
+	type Form struct {
+		A
+		B
+		Name string `vee:"label:'Outer Name'"`
+	}
+
+	var names []string
+	for _, fs := range schemaFor(reflect.TypeOf(Form{})).fields {
+		names = append(names, fmt.Sprint(fs.field.Name, fs.field.Index))
+	}
+
+	// Outer Name is shallowest, Title is ambiguous, B.Code is tagged
+	want := []string{"Code[1 1]", "Name[2]"}
+	if !slices.Equal(names, want) {
+		t.Errorf("fields = %v, want %v", names, want)
+	}
+
+	got, err := Render(Form{A: A{Name: "inner"}, B: B{Code: "b"}, Name: "outer"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(got, `value="outer"`) || strings.Contains(got, `value="inner"`) || strings.Contains(got, "title") {
+		t.Errorf("Render() = %s, want outer Name and no Title", got)
+	}
+}
+
+func TestEmbeddedTags(t *testing.T) {
+	type Fax struct {
+		Fax string
+	}
+	type Form struct {
+		embeddedInternal `vee:"-"`
+		Contact          embeddedContact `vee:"$contact"`
+		Audit            embeddedAudit   `vee:"-"`
+		Fax              `vee:"$extra,label:'Extra Contact'"`
+	}
+
+	got, err := Render(Form{})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if strings.Contains(got, "token") || strings.Contains(got, "created_by") {
+		t.Errorf("Render() = %s, want skipped embedded structs dropped", got)
+	}
+	expected := []string{
+		`<input type="email" name="contact.email" value="" id="contact.email">`,
+		`<fieldset id="extra"><legend>Extra Contact</legend>`,
+		`<input type="text" name="extra.fax" value="" id="extra.fax">`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, got)
+		}
+	}
+}
+
+func TestEmbeddedValidationErrors(t *testing.T) {
+	user := embeddedUser{embeddedPrefs: embeddedPrefs{ColorChoices: []string{"Red"}}}
+
+	got, err := RenderWithErrors(user, Validate(user))
+	if err != nil {
+		t.Fatalf("RenderWithErrors() error = %v", err)
+	}
+	if !strings.Contains(got, `<span id="created_by_error">This field is required</span>`) {
+		t.Errorf("RenderWithErrors() = %s, want error for promoted CreatedBy", got)
+	}
+}
@@ -0,0 +1,329 @@
+package vee
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldErrors maps Go struct field names to the error messages that should be
+// displayed next to the corresponding form inputs.
+type FieldErrors map[string][]string
+
+// Add appends a message for the given struct field.
+func (fe FieldErrors) Add(field, message string) {
+	fe[field] = append(fe[field], message)
+}
+
+// Get returns the messages recorded for the given struct field.
+func (fe FieldErrors) Get(field string) []string {
+	return fe[field]
+}
+
+// Sentinel errors for structural problems with the values passed to Render and Bind.
+var (
+	// ErrNotPointer is returned when Bind is not given a pointer.
+	ErrNotPointer = errors.New("vee: expected pointer to struct")
+
+	// ErrNotStruct is returned when the value does not refer to a struct.
+	ErrNotStruct = errors.New("vee: expected struct")
+
+	// ErrFormData is returned when Bind is given form data of an unsupported type.
+	ErrFormData = errors.New("vee: expected url.Values, map[string][]string or *multipart.Form")
+
+	// ErrFieldNotFound is returned when a field requested by name is not rendered.
+	ErrFieldNotFound = errors.New("vee: field not found")
+
+	// ErrUnsupportedHidden is returned by Render when the hidden attribute is
+	// used on a field type that cannot be rendered as a hidden input.
+	ErrUnsupportedHidden = errors.New("vee: hidden attribute not supported")
+
+	// ErrTimeZone is returned when the tz attribute of a time field names an
+	// unknown time zone.
+	ErrTimeZone = errors.New("vee: unknown time zone")
+
+	// ErrChoicesChosen is returned when {Name}Choices and {Name}Chosen fields
+	// are unpaired, have unsupported types, or hold inconsistent values.
+	ErrChoicesChosen = errors.New("vee: invalid Choices/Chosen fields")
+)
+
+// StructError describes a structural problem with a struct or one of its fields.
+// It wraps one of the sentinel errors so it can be matched with errors.Is.
+type StructError struct {
+	Field string // Go struct field name, empty if the error concerns the whole struct
+	Err   error  // Sentinel error
+	msg   string
+}
+
+func (e *StructError) Error() string {
+	return e.msg
+}
+
+func (e *StructError) Unwrap() error {
+	return e.Err
+}
+
+// structError creates a StructError for a field with a formatted message
+func structError(sentinel error, field string, format string, args ...any) error {
+	return &StructError{
+		Field: field,
+		Err:   sentinel,
+		msg:   fmt.Sprintf(format, args...),
+	}
+}
+
+// Expected kinds reported by FieldError.
+const (
+	KindInteger  = "integer"
+	KindFloat    = "float"
+	KindBool     = "boolean"
+	KindTime     = "time"
+	KindDuration = "duration"
+	KindChoice   = "choice"
+	KindRows     = "row count"
+	KindText     = "text"
+	KindFile     = "file"
+	KindColor    = "color"
+)
+
+// FieldError describes a failure to bind a single form value to a struct field.
+type FieldError struct {
+	Field string // Go struct field path (Address.Street for nested fields)
+	Name  string // HTML form field name
+	Value string // Raw submitted value
+	Kind  string // Expected kind of value (KindInteger, KindFloat, ...)
+	Err   error  // Underlying cause
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("vee: cannot parse '%s' as %s for field '%s': %v", e.Value, e.Kind, e.Name, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Message returns a human-readable message suitable for display next to the field.
+func (e *FieldError) Message() string {
+	var rangeErr *RangeError
+	if errors.As(e.Err, &rangeErr) {
+		return rangeErr.message()
+	}
+
+	switch e.Kind {
+	case KindInteger:
+		return "Must be a whole number"
+	case KindFloat:
+		return "Must be a number"
+	case KindBool:
+		return "Must be true or false"
+	case KindTime:
+		return "Must be a valid date or time"
+	case KindDuration:
+		return "Must be a valid duration"
+	case KindChoice:
+		if errors.Is(e.Err, errChoiceRequired) {
+			return "Must choose an option"
+		}
+		return "Must be one of the available choices"
+	case KindColor:
+		return "Must be a color like #ff0000"
+	case KindFile:
+		var fileErr *fileError
+		if errors.As(e.Err, &fileErr) {
+			return fileErr.message()
+		}
+		return "Invalid file"
+	case KindRows:
+		var rowErr *rowCountError
+		if errors.As(e.Err, &rowErr) {
+			return rowErr.message()
+		}
+		return "Invalid number of rows"
+	default:
+		return "Invalid value"
+	}
+}
+
+// RangeError reports a number outside the range of a numeric field type.
+type RangeError struct {
+	Max   bool   // Whether the maximum (or else the minimum) was exceeded
+	Limit string // The exceeded limit of the type, e.g. "127" for int8
+	Err   error  // The strconv error wrapping strconv.ErrRange
+}
+
+func (e *RangeError) Error() string {
+	if e.Max {
+		return "value out of range, maximum is " + e.Limit
+	}
+	return "value out of range, minimum is " + e.Limit
+}
+
+func (e *RangeError) Unwrap() error {
+	return e.Err
+}
+
+// message returns a human-readable message for the exceeded bound
+func (e *RangeError) message() string {
+	if e.Max {
+		return "Must be at most " + e.Limit
+	}
+	return "Must be at least " + e.Limit
+}
+
+// fileError reports an uploaded file that is larger than the maxsize attribute
+// or not accepted by the accept attribute of its field
+type fileError struct {
+	filename string
+	maxSize  int64  // Exceeded maximum size, 0 if the type is not accepted
+	accept   string // Accepted file types
+}
+
+func (e *fileError) Error() string {
+	if e.maxSize > 0 {
+		return fmt.Sprintf("file '%s' is larger than %s", e.filename, formatByteSize(e.maxSize))
+	}
+	return fmt.Sprintf("file '%s' is not of an accepted type (%s)", e.filename, e.accept)
+}
+
+// message returns a human-readable message for the file error
+func (e *fileError) message() string {
+	if e.maxSize > 0 {
+		return "File must be at most " + formatByteSize(e.maxSize)
+	}
+	return "File must be of type " + strings.ReplaceAll(e.accept, ",", ", ")
+}
+
+// formatByteSize formats a size in bytes using the largest unit of
+// parseByteSize that divides it
+func formatByteSize(size int64) string {
+	for _, unit := range byteSizeUnits {
+		if size%unit.size == 0 {
+			return strconv.FormatInt(size/unit.size, 10) + " " + unit.suffix
+		}
+	}
+	return strconv.FormatInt(size, 10) + " B"
+}
+
+// rowCountError reports a number of submitted slice rows outside the
+// configured min/max row counts
+type rowCountError struct {
+	count, min, max int
+}
+
+func (e *rowCountError) Error() string {
+	if e.count < e.min {
+		return fmt.Sprintf("%d rows are fewer than the minimum of %d", e.count, e.min)
+	}
+	return fmt.Sprintf("%d rows exceed the maximum of %d", e.count, e.max)
+}
+
+// message returns a human-readable message for the row count
+func (e *rowCountError) message() string {
+	if e.count < e.min {
+		return "Must have at least " + pluralRows(e.min)
+	}
+	return "Must have at most " + pluralRows(e.max)
+}
+
+// pluralRows returns the number of rows with the matching noun
+func pluralRows(n int) string {
+	if n == 1 {
+		return "1 row"
+	}
+	return strconv.Itoa(n) + " rows"
+}
+
+// BindErrors collects the FieldErrors of all fields that failed to bind.
+type BindErrors []*FieldError
+
+func (e BindErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e BindErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fieldErr := range e {
+		errs[i] = fieldErr
+	}
+	return errs
+}
+
+// CollectErrors converts the errors returned by Validate and Bind into
+// FieldErrors suitable for rendering. Errors that cannot be attributed to a
+// field are ignored.
+func CollectErrors(err error) FieldErrors {
+	collected := make(FieldErrors)
+	if err == nil {
+		return collected
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, fe := range validationErrs {
+			collected.Add(validationFieldPath(fe), validationMessage(fe))
+		}
+	}
+
+	var bindErrs BindErrors
+	var fieldErr *FieldError
+	if errors.As(err, &bindErrs) {
+		for _, fieldErr := range bindErrs {
+			collected.Add(fieldErr.Field, fieldErr.Message())
+		}
+	} else if errors.As(err, &fieldErr) {
+		collected.Add(fieldErr.Field, fieldErr.Message())
+	}
+
+	return collected
+}
+
+// validationFieldPath returns the struct field path of a validation error
+// without the name of the top-level struct.
+func validationFieldPath(fe validator.FieldError) string {
+	namespace := fe.StructNamespace()
+	if idx := strings.Index(namespace, "."); idx >= 0 {
+		return namespace[idx+1:]
+	}
+	return fe.StructField()
+}
+
+// validationMessage returns a human-readable message for a validation error
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "This field is required"
+	case "email":
+		return "Must be a valid email address"
+	case "url", "http_url":
+		return "Must be a valid URL"
+	case "min", "gte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("Must be at least %s characters", fe.Param())
+		}
+		return fmt.Sprintf("Must be at least %s", fe.Param())
+	case "max", "lte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("Must be at most %s characters", fe.Param())
+		}
+		return fmt.Sprintf("Must be at most %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("Must be greater than %s", fe.Param())
+	case "lt":
+		return fmt.Sprintf("Must be less than %s", fe.Param())
+	case "len":
+		return fmt.Sprintf("Must have length %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("Must be one of: %s", fe.Param())
+	default:
+		return "Invalid value"
+	}
+}
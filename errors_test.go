@@ -0,0 +1,256 @@
+package vee
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCollectErrors(t *testing.T) {
+	type User struct {
+		Name  string `validate:"required"`
+		Email string `validate:"required,email"`
+		Age   int    `validate:"gte=18"`
+	}
+
+	err := Validate(User{Email: "invalid", Age: 10})
+	got := CollectErrors(err)
+
+	want := map[string]string{
+		"Name":  "This field is required",
+		"Email": "Must be a valid email address",
+		"Age":   "Must be at least 18",
+	}
+	for field, message := range want {
+		messages := got.Get(field)
+		if len(messages) != 1 || messages[0] != message {
+			t.Errorf("CollectErrors()[%q] = %v, want [%q]", field, messages, message)
+		}
+	}
+
+	bindErr := Bind(map[string][]string{"age": {"abc"}}, &User{})
+	got = CollectErrors(bindErr)
+	if messages := got.Get("Age"); len(messages) != 1 || messages[0] != "Must be a whole number" {
+		t.Errorf("CollectErrors()[\"Age\"] for bind error = %v, want [\"Must be a whole number\"]", messages)
+	}
+
+	if got := CollectErrors(nil); len(got) != 0 {
+		t.Errorf("CollectErrors(nil) = %v, want empty", got)
+	}
+	if got := CollectErrors(errors.New("unrelated")); len(got) != 0 {
+		t.Errorf("CollectErrors(unrelated) = %v, want empty", got)
+	}
+}
+
+func TestRenderWithErrors(t *testing.T) {
+	type User struct {
+		Name  string `validate:"required"`
+		Email string `vee:"type:'email'" validate:"required,email" css:"input" errorCss:"input-invalid"`
+		Age   int
+	}
+
+	user := User{Email: "invalid", Age: 30}
+	html, err := RenderWithErrors(user, Validate(user))
+	if err != nil {
+		t.Fatalf("RenderWithErrors() error = %v", err)
+	}
+
+	want := `<form method="POST">
+<label for="name">Name</label>
+<input type="text" name="name" value="" class="error" id="name" aria-invalid="true" aria-describedby="name_error">
+<span id="name_error">This field is required</span>
+<label for="email">Email</label>
+<input type="email" name="email" value="invalid" class="input input-invalid" id="email" aria-invalid="true" aria-describedby="email_error">
+<span id="email_error">Must be a valid email address</span>
+<label for="age">Age</label>
+<input type="number" name="age" value="30" id="age">
+</form>
+`
+	if html != want {
+		t.Errorf("RenderWithErrors() = %q, want %q", html, want)
+	}
+
+	// The errors option must not be appended to the caller's options
+	opts := make([]RenderOption, 1, 2)
+	spare := opts[:2]
+	if _, err := RenderWithErrors(user, Validate(user), opts...); err != nil {
+		t.Fatalf("RenderWithErrors() error = %v", err)
+	}
+	if spare[1].Errors != nil {
+		t.Errorf("RenderWithErrors() wrote the errors option into the caller's options")
+	}
+}
+
+func TestRenderErrorOptions(t *testing.T) {
+	type Form struct {
+		Name        string `vee:"id:'full_name'"`
+		SizeChoices []string
+		SizeChosen  int `vee:"type:'radio'"`
+	}
+
+	errs := make(FieldErrors)
+	errs.Add("Name", "Too short")
+	errs.Add("Name", "Contains <script>")
+	errs.Add("SizeChosen", "Pick a size")
+
+	html, err := Render(Form{Name: "J", SizeChoices: []string{"S", "M"}},
+		RenderOption{Errors: errs},
+		ErrorCSSOption("is-invalid"),
+		ErrorMessageCSSOption("text-red-600"),
+	)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := []string{
+		`<input type="text" name="name" value="J" class="is-invalid" id="full_name" aria-invalid="true" aria-describedby="full_name_error">`,
+		`<span id="full_name_error" class="text-red-600">Too short<br>Contains &lt;script&gt;</span>`,
+		`id="size_chosen_0" aria-invalid="true" aria-describedby="size_chosen_error">`,
+		`id="size_chosen_1" aria-invalid="true" aria-describedby="size_chosen_error">`,
+		"</fieldset>\n<span id=\"size_chosen_error\" class=\"text-red-600\">Pick a size</span>",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(html, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, html)
+		}
+	}
+}
+
+func TestRenderWithoutErrorsUnchanged(t *testing.T) {
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	plain, err := Render(User{Name: "John"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	withErrors, err := RenderWithErrors(User{Name: "John"}, Validate(User{Name: "John"}))
+	if err != nil {
+		t.Fatalf("RenderWithErrors() error = %v", err)
+	}
+	if plain != withErrors {
+		t.Errorf("RenderWithErrors() with no errors = %q, want %q", withErrors, plain)
+	}
+}
+
+func TestBindErrorsAggregate(t *testing.T) {
+	type Order struct {
+		Quantity     int
+		Price        float64
+		Weight       float64
+		Name         string
+		ColorChoices []string
+		ColorChosen  int
+	}
+
+	order := Order{ColorChoices: []string{"Red", "Blue"}}
+	err := Bind(map[string][]string{
+		"quantity":     {"many"},
+		"price":        {"cheap"},
+		"weight":       {"1.5"},
+		"name":         {"Widget"},
+		"color_chosen": {"7"},
+	}, &order)
+
+	var bindErrs BindErrors
+	if !errors.As(err, &bindErrs) {
+		t.Fatalf("Bind() error = %v, want BindErrors", err)
+	}
+	if len(bindErrs) != 3 {
+		t.Fatalf("Bind() returned %d errors, want 3: %v", len(bindErrs), err)
+	}
+
+	want := []FieldError{
+		{Field: "Quantity", Name: "quantity", Value: "many", Kind: KindInteger},
+		{Field: "Price", Name: "price", Value: "cheap", Kind: KindFloat},
+		{Field: "ColorChosen", Name: "color_chosen", Value: "7", Kind: KindChoice},
+	}
+	for i, w := range want {
+		got := bindErrs[i]
+		if got.Field != w.Field || got.Name != w.Name || got.Value != w.Value || got.Kind != w.Kind || got.Err == nil {
+			t.Errorf("BindErrors[%d] = %+v, want %+v with cause", i, got, w)
+		}
+	}
+
+	// Valid fields are still bound
+	if order.Weight != 1.5 || order.Name != "Widget" {
+		t.Errorf("Bind() result = %+v, want Weight=1.5, Name='Widget'", order)
+	}
+
+	// Causes are reachable through the error chain
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("errors.As(err, *strconv.NumError) = false, want true")
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Quantity" {
+		t.Errorf("errors.As(err, *FieldError) = %+v, want first field error", fieldErr)
+	}
+}
+
+func TestBindStructuralErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		target   any
+		sentinel error
+	}{
+		{
+			name:     "non-pointer target",
+			input:    map[string][]string{},
+			target:   struct{}{},
+			sentinel: ErrNotPointer,
+		},
+		{
+			name:     "pointer to non-struct",
+			input:    map[string][]string{},
+			target:   new(string),
+			sentinel: ErrNotStruct,
+		},
+		{
+			name:     "unsupported form data",
+			input:    "wrong type",
+			target:   &struct{}{},
+			sentinel: ErrFormData,
+		},
+		{
+			name:  "orphaned Chosen field",
+			input: map[string][]string{},
+			target: &struct {
+				ColorChosen int
+			}{},
+			sentinel: ErrChoicesChosen,
+		},
+		{
+			name:  "empty Choices",
+			input: map[string][]string{},
+			target: &struct {
+				ColorChoices []string
+				ColorChosen  int
+			}{},
+			sentinel: ErrChoicesChosen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bind(tt.input, tt.target)
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("Bind() error = %v, want errors.Is %v", err, tt.sentinel)
+			}
+		})
+	}
+
+	err := Bind(map[string][]string{}, &struct{ ColorChosen int }{})
+	var structErr *StructError
+	if !errors.As(err, &structErr) || structErr.Field != "ColorChosen" {
+		t.Errorf("errors.As(err, *StructError) = %+v, want Field 'ColorChosen'", structErr)
+	}
+
+	if _, err := Render("not a struct"); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("Render() error = %v, want errors.Is ErrNotStruct", err)
+	}
+}
@@ -0,0 +1,171 @@
+package vee
+
+import (
+	"errors"
+	"testing"
+)
+
+type fieldTestForm struct {
+	Name         string `css:"input" labelCss:"label"`
+	Email        string `vee:"$user_email,type:'email',nolabel"`
+	ColorChoices []string
+	ColorChosen  int `vee:"label:'Favorite Color'"`
+	SizeChoices  []string
+	SizeChosen   int    `vee:"type:'radio'"`
+	Phase        int    `vee:"hidden"`
+	Internal     string `vee:"-"`
+}
+
+func newFieldTestForm() fieldTestForm {
+	return fieldTestForm{
+		Name:         "John",
+		Email:        "john@example.com",
+		ColorChoices: []string{"Red", "Blue"},
+		ColorChosen:  1,
+		SizeChoices:  []string{"S", "M"},
+		Phase:        2,
+	}
+}
+
+func TestRenderField(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func(v any, name string, opts ...RenderOption) (string, error)
+		field  string
+		want   string
+	}{
+		{
+			name:   "field with label and css",
+			render: RenderField,
+			field:  "Name",
+			want: `<label for="name" class="label">Name</label>
+<input type="text" name="name" value="John" class="input" id="name">
+`,
+		},
+		{
+			name:   "field with name override and nolabel",
+			render: RenderField,
+			field:  "Email",
+			want: `<input type="email" name="user_email" value="john@example.com" id="user_email">
+`,
+		},
+		{
+			name:   "select field uses choices",
+			render: RenderField,
+			field:  "ColorChosen",
+			want: `<label for="color_chosen">Favorite Color</label>
+<select name="color_chosen" id="color_chosen">
+<option value="0">Red</option>
+<option value="1" selected>Blue</option>
+</select>
+`,
+		},
+		{
+			name:   "hidden field",
+			render: RenderField,
+			field:  "Phase",
+			want: `<input type="hidden" name="phase" value="2" id="phase">
+`,
+		},
+		{
+			name:   "label only",
+			render: RenderLabel,
+			field:  "Name",
+			want: `<label for="name" class="label">Name</label>
+`,
+		},
+		{
+			name:   "label of nolabel field is empty",
+			render: RenderLabel,
+			field:  "Email",
+			want:   "",
+		},
+		{
+			name:   "label of hidden field is empty",
+			render: RenderLabel,
+			field:  "Phase",
+			want:   "",
+		},
+		{
+			name:   "input only",
+			render: RenderInput,
+			field:  "Name",
+			want: `<input type="text" name="name" value="John" class="input" id="name">
+`,
+		},
+		{
+			name:   "input of radio group includes fieldset",
+			render: RenderInput,
+			field:  "SizeChosen",
+			want: `<fieldset><legend>Size Chosen</legend>
+<input type="radio" name="size_chosen" value="0" checked id="size_chosen_0"><label for="size_chosen_0">S</label>
+<input type="radio" name="size_chosen" value="1" id="size_chosen_1"><label for="size_chosen_1">M</label>
+</fieldset>
+`,
+		},
+		{
+			name:   "errors of field without errors are empty",
+			render: RenderErrors,
+			field:  "Name",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.render(newFieldTestForm(), tt.field)
+			if err != nil {
+				t.Fatalf("render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderFieldErrors(t *testing.T) {
+	errs := make(FieldErrors)
+	errs.Add("Name", "Too short")
+
+	got, err := RenderErrors(newFieldTestForm(), "Name", RenderOption{Errors: errs}, ErrorMessageCSSOption("msg"))
+	if err != nil {
+		t.Fatalf("RenderErrors() error = %v", err)
+	}
+	want := "<span id=\"name_error\" class=\"msg\">Too short</span>\n"
+	if got != want {
+		t.Errorf("RenderErrors() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderFieldNotFound(t *testing.T) {
+	for _, name := range []string{"Missing", "Internal", "ColorChoices"} {
+		if _, err := RenderField(newFieldTestForm(), name); !errors.Is(err, ErrFieldNotFound) {
+			t.Errorf("RenderField(%q) error = %v, want ErrFieldNotFound", name, err)
+		}
+	}
+
+	// Structural errors are reported like Render
+	_, err := RenderField(struct{ ColorChosen int }{}, "ColorChosen")
+	if !errors.Is(err, ErrChoicesChosen) {
+		t.Errorf("RenderField() error = %v, want ErrChoicesChosen", err)
+	}
+}
+
+func TestRenderFields(t *testing.T) {
+	form := newFieldTestForm()
+
+	full, err := Render(form, InputCSSOption("default"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	fields, err := RenderFields(form, InputCSSOption("default"))
+	if err != nil {
+		t.Fatalf("RenderFields() error = %v", err)
+	}
+
+	want := full[len("<form method=\"POST\">\n") : len(full)-len("</form>\n")]
+	if fields != want {
+		t.Errorf("RenderFields() = %q, want %q", fields, want)
+	}
+}
@@ -0,0 +1,197 @@
+package vee
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+type fileProfile struct {
+	Name        string
+	Avatar      *multipart.FileHeader   `vee:"accept:'image/*',capture:'user',maxsize:'1KB'"`
+	Attachments []*multipart.FileHeader `vee:"accept:'.pdf,.txt'"`
+}
+
+type fileUpload struct {
+	name, filename, contentType, content string
+}
+
+// fileRequest builds a multipart POST request with the given values and files
+func fileRequest(t *testing.T, values map[string]string, files ...fileUpload) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for name, value := range values {
+		if err := mw.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="`+file.name+`"; filename="`+file.filename+`"`)
+		header.Set("Content-Type", file.contentType)
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte(file.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("POST", "/profile?ref=mail", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestFileRendering(t *testing.T) {
+	got, err := Render(fileProfile{Name: "John"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<form method="POST" enctype="multipart/form-data">
+<label for="name">Name</label>
+<input type="text" name="name" value="John" id="name">
+<label for="avatar">Avatar</label>
+<input type="file" name="avatar" accept="image/*" capture="user" id="avatar">
+<label for="attachments">Attachments</label>
+<input type="file" name="attachments" accept=".pdf,.txt" multiple id="attachments">
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// Forms with file inputs in nested structs or row templates are multipart too
+	type Document struct {
+		File *multipart.FileHeader
+	}
+	type Folder struct {
+		Documents []Document
+	}
+	got, err = Render(Folder{})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.HasPrefix(got, `<form method="POST" enctype="multipart/form-data">`) {
+		t.Errorf("Render() = %s, want multipart form", got)
+	}
+
+	got, err = Render(struct{ Name string }{})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(got, "enctype") {
+		t.Errorf("Render() = %s, want no enctype without file inputs", got)
+	}
+}
+
+func TestFileBindRequest(t *testing.T) {
+	req := fileRequest(t, map[string]string{"name": "John"},
+		fileUpload{"avatar", "me.png", "image/png", "png"},
+		fileUpload{"attachments", "a.pdf", "application/pdf", "pdf"},
+		fileUpload{"attachments", "b.TXT", "text/plain", "txt"},
+	)
+
+	var form struct {
+		fileProfile
+		Ref string
+	}
+	if err := BindRequest(req, &form); err != nil {
+		t.Fatalf("BindRequest() error = %v", err)
+	}
+
+	if form.Name != "John" || form.Ref != "mail" {
+		t.Errorf("BindRequest() = %+v, want text fields and query values bound", form)
+	}
+	if form.Avatar == nil || form.Avatar.Filename != "me.png" || form.Avatar.Size != 3 {
+		t.Errorf("BindRequest() Avatar = %+v, want me.png", form.Avatar)
+	}
+	if len(form.Attachments) != 2 || form.Attachments[0].Filename != "a.pdf" || form.Attachments[1].Filename != "b.TXT" {
+		t.Errorf("BindRequest() Attachments = %+v, want a.pdf and b.TXT", form.Attachments)
+	}
+
+	// Plain forms are still parsed
+	req = httptest.NewRequest("POST", "/", strings.NewReader("name=Jane"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var profile fileProfile
+	if err := BindRequest(req, &profile); err != nil || profile.Name != "Jane" {
+		t.Errorf("BindRequest() = (%v, %+v), want Name 'Jane'", err, profile)
+	}
+}
+
+func TestFileBindingErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		upload  fileUpload
+		message string
+	}{
+		{"too large", fileUpload{"avatar", "big.png", "image/png", strings.Repeat("x", 1025)}, "File must be at most 1 KB"},
+		{"wrong mime type", fileUpload{"avatar", "me.gif", "text/plain", "gif"}, "File must be of type image/*"},
+		{"wrong extension", fileUpload{"attachments", "run.exe", "application/pdf", "exe"}, "File must be of type .pdf, .txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var profile fileProfile
+			err := BindRequest(fileRequest(t, nil, tt.upload), &profile)
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Kind != KindFile || fieldErr.Value != tt.upload.filename {
+				t.Fatalf("BindRequest() error = %v, want file FieldError", err)
+			}
+			if msg := fieldErr.Message(); msg != tt.message {
+				t.Errorf("Message() = %q, want %q", msg, tt.message)
+			}
+			if profile.Avatar != nil || profile.Attachments != nil {
+				t.Errorf("BindRequest() = %+v, want files not bound", profile)
+			}
+		})
+	}
+}
+
+func TestFileBindMultipartForm(t *testing.T) {
+	type Attachment struct {
+		File *multipart.FileHeader
+	}
+	type Message struct {
+		Subject     string
+		Attachments []Attachment
+	}
+
+	pdf := &multipart.FileHeader{Filename: "a.pdf"}
+	var msg Message
+	err := Bind(&multipart.Form{
+		Value: map[string][]string{"subject": {"Hi"}},
+		File:  map[string][]*multipart.FileHeader{"attachments[0].file": {pdf}},
+	}, &msg)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if msg.Subject != "Hi" || len(msg.Attachments) != 1 || msg.Attachments[0].File != pdf {
+		t.Errorf("Bind() = %+v, want row with only a file field", msg)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{"512": 512, "100B": 100, "2KB": 2048, "5MB": 5 << 20, "1 GB": 1 << 30}
+	for s, want := range tests {
+		if got, err := parseByteSize(s); err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = (%d, %v), want %d", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "MB", "-1", "1TB", "99999999999GB"} {
+		if _, err := parseByteSize(s); err == nil {
+			t.Errorf("parseByteSize(%q) error = nil, want error", s)
+		}
+	}
+}
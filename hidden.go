@@ -0,0 +1,132 @@
+package vee
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Hidden inputs use a single codec for rendering and binding, so that any
+// value rendered by vee binds back to the same value. Values are formatted
+// without loss: times as RFC 3339 with nanoseconds, durations as nanoseconds
+// and bools as true or false. Nil pointers render without a value, and empty
+// submitted values bind as nil pointers.
+
+// hiddenKind reports whether values of a field kind can be stored in a
+// hidden input
+func hiddenKind(kind fieldKind) bool {
+	switch kind {
+	case kindString, kindInt, kindUint, kindFloat, kindBool, kindTime, kindDuration, kindText, kindCodec:
+		return true
+	}
+	return false
+}
+
+// hiddenCodec reports whether values of a codec type can be stored in a
+// hidden input. Codecs writing their own control may submit several values,
+// so they need to implement HiddenCodec.
+func hiddenCodec(typ reflect.Type) bool {
+	codec := codecFor(typ)
+	if _, ok := codec.(HiddenCodec); ok {
+		return true
+	}
+	_, ok := codec.(ControlWriter)
+	return !ok
+}
+
+// formatHidden formats a value of a field kind for a hidden input
+func formatHidden(kind fieldKind, v reflect.Value) (string, error) {
+	switch kind {
+	case kindString:
+		return v.String(), nil
+	case kindInt:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case kindUint:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case kindFloat:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case kindBool:
+		return strconv.FormatBool(v.Bool()), nil
+	case kindTime:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	case kindDuration:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case kindText:
+		return marshalText(v)
+	case kindCodec:
+		codec := codecFor(v.Type())
+		if hidden, ok := codec.(HiddenCodec); ok {
+			return hidden.FormatHidden(v)
+		}
+		var f Field
+		if err := codec.Render(&f, v); err != nil {
+			return "", err
+		}
+		return f.Value, nil
+	}
+	return "", fmt.Errorf("unsupported kind for hidden value: %v", v.Kind())
+}
+
+// parseHidden parses a value formatted by formatHidden into a new value of
+// type typ. On failure it also returns the expected kind for the FieldError.
+func parseHidden(kind fieldKind, value string, typ reflect.Type) (reflect.Value, string, error) {
+	v := reflect.New(typ).Elem()
+	switch kind {
+	case kindString:
+		v.SetString(value)
+	case kindInt:
+		n, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return v, KindInteger, numberError(err, value, typ)
+		}
+		v.SetInt(n)
+	case kindUint:
+		n, err := parseUint(value, typ.Bits())
+		if err != nil {
+			return v, KindInteger, numberError(err, value, typ)
+		}
+		v.SetUint(n)
+	case kindFloat:
+		n, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return v, KindFloat, numberError(err, value, typ)
+		}
+		v.SetFloat(n)
+	case kindBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return v, KindBool, err
+		}
+		v.SetBool(b)
+	case kindTime:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return v, KindTime, err
+		}
+		v.Set(reflect.ValueOf(t))
+	case kindDuration:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return v, KindDuration, err
+		}
+		v.SetInt(n)
+	case kindText:
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return v, KindText, err
+		}
+	case kindCodec:
+		codec := codecFor(typ)
+		var err error
+		if hidden, ok := codec.(HiddenCodec); ok {
+			err = hidden.ParseHidden(v, value)
+		} else {
+			err = codec.Bind(v, []string{value})
+		}
+		if err != nil {
+			return v, typ.String(), err
+		}
+	}
+	return v, "", nil
+}
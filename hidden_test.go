@@ -1,6 +1,13 @@
 package vee
 
 import (
+	"errors"
+	"html"
+	"math"
+	"mime/multipart"
+	"net/netip"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -153,16 +160,30 @@ func TestHiddenFieldErrors(t *testing.T) {
 		expected string
 	}{
 		{
-			name: "pointer type not supported",
+			name: "struct type not supported",
 			input: struct {
-				Name *string `vee:"hidden"`
+				Address struct{ Street string } `vee:"hidden"`
 			}{},
-			expected: "hidden attribute not supported for pointer type 'Name'",
+			expected: "hidden attribute not supported for type 'struct { Street string }' of field 'Address'",
 		},
 		{
-			name: "slice type not supported",
+			name: "file type not supported",
 			input: struct {
-				Items []string `vee:"hidden"`
+				Avatar *multipart.FileHeader `vee:"hidden"`
+			}{},
+			expected: "hidden attribute not supported for type '*multipart.FileHeader' of field 'Avatar'",
+		},
+		{
+			name: "slice of structs not supported",
+			input: struct {
+				Items []struct{ Name string } `vee:"hidden"`
+			}{},
+			expected: "hidden attribute not supported for type '[]struct { Name string }' of field 'Items'",
+		},
+		{
+			name: "slice pointer not supported",
+			input: struct {
+				Items *[]string `vee:"hidden"`
 			}{},
 			expected: "hidden attribute not supported for slice/array type 'Items'",
 		},
@@ -181,14 +202,6 @@ func TestHiddenFieldErrors(t *testing.T) {
 			}{},
 			expected: "hidden attribute not supported for multi-value field 'ColorChoices'",
 		},
-		{
-			name: "chosen field not supported",
-			input: struct {
-				ColorChoices []string
-				ColorChosen  int `vee:"hidden"`
-			}{},
-			expected: "hidden attribute not supported for multi-value field 'ColorChosen'",
-		},
 	}
 
 	for _, tt := range tests {
@@ -294,3 +307,187 @@ func TestHiddenFieldBinding(t *testing.T) {
 		})
 	}
 }
+
+// hiddenInputs returns the submitted values of the hidden inputs in html, in
+// document order
+func hiddenInputs(t *testing.T, out string) map[string][]string {
+	t.Helper()
+	re := regexp.MustCompile(`<input type="hidden" name="([^"]*)"(?: value="([^"]*)")?`)
+	values := make(map[string][]string)
+	for _, m := range re.FindAllStringSubmatch(out, -1) {
+		values[m[1]] = append(values[m[1]], html.UnescapeString(m[2]))
+	}
+	return values
+}
+
+type hiddenState struct {
+	Name     string          `vee:"hidden"`
+	Count    int8            `vee:"hidden"`
+	Total    uint64          `vee:"hidden"`
+	Ratio    float32         `vee:"hidden"`
+	Active   bool            `vee:"hidden"`
+	Created  time.Time       `vee:"hidden"`
+	Timeout  time.Duration   `vee:"hidden"`
+	Addr     netip.Addr      `vee:"hidden"`
+	Owner    *string         `vee:"hidden"`
+	Limit    *int            `vee:"hidden"`
+	Deadline *time.Time      `vee:"hidden"`
+	Tags     []string        `vee:"hidden"`
+	Steps    []time.Duration `vee:"hidden"`
+
+	SizeChoices  []string
+	SizeChosen   int `vee:"hidden"`
+	ColorChoices []string
+	ColorChosen  []int `vee:"hidden"`
+}
+
+func TestHiddenRoundTrip(t *testing.T) {
+	owner := "alice"
+	limit := 0
+	tests := []struct {
+		name  string
+		input hiddenState
+	}{
+		{
+			name: "values",
+			input: hiddenState{
+				Name:         `a "quoted" name`,
+				Count:        -128,
+				Total:        math.MaxUint64,
+				Ratio:        0.1,
+				Active:       true,
+				Created:      time.Date(2024, 3, 15, 14, 30, 45, 123456789, time.UTC),
+				Timeout:      1500*time.Millisecond + 7,
+				Addr:         netip.MustParseAddr("192.168.0.1"),
+				Owner:        &owner,
+				Limit:        &limit,
+				Tags:         []string{"b", "a", "b"},
+				Steps:        []time.Duration{time.Second, 0},
+				SizeChoices:  []string{"S", "M", "L"},
+				SizeChosen:   2,
+				ColorChoices: []string{"Red", "Green", "Blue"},
+				ColorChosen:  []int{2, 0},
+			},
+		},
+		{
+			name: "zero values",
+			input: hiddenState{
+				Tags:         []string{},
+				Steps:        []time.Duration{},
+				SizeChoices:  []string{"S"},
+				ColorChoices: []string{"Red"},
+				ColorChosen:  []int{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Render(tt.input)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			// Bind into a struct with different values to see every field set
+			other := "bob"
+			got := hiddenState{
+				Name:         "other",
+				Count:        1,
+				Active:       !tt.input.Active,
+				Created:      time.Now(),
+				Timeout:      time.Hour,
+				Owner:        &other,
+				Deadline:     &time.Time{},
+				Tags:         []string{"other"},
+				Steps:        []time.Duration{time.Minute},
+				SizeChoices:  tt.input.SizeChoices,
+				ColorChoices: tt.input.ColorChoices,
+				ColorChosen:  []int{0},
+			}
+			if err := Bind(hiddenInputs(t, out), &got); err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.input) {
+				t.Errorf("Bind(Render()) = %+v\nwant %+v\nhtml %s", got, tt.input, out)
+			}
+		})
+	}
+}
+
+func TestHiddenMultipleValues(t *testing.T) {
+	got, err := Render(struct {
+		Items        []int `vee:"hidden"`
+		ColorChoices []string
+		ColorChosen  []int `vee:"hidden"`
+		Empty        []int `vee:"hidden"`
+	}{
+		Items:        []int{3, 1},
+		ColorChoices: []string{"Red", "Green"},
+		ColorChosen:  []int{1},
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<form method="POST">
+<input type="hidden" name="items" value="" id="items">
+<input type="hidden" name="items" value="3" id="items_0">
+<input type="hidden" name="items" value="1" id="items_1">
+<input type="hidden" name="color_chosen" value="" id="color_chosen">
+<input type="hidden" name="color_chosen" value="1" id="color_chosen_0">
+<input type="hidden" name="empty" value="" id="empty">
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestHiddenBindingErrors(t *testing.T) {
+	type State struct {
+		Active  bool          `vee:"hidden"`
+		Items   []uint8       `vee:"hidden"`
+		Created time.Time     `vee:"hidden"`
+		Timeout time.Duration `vee:"hidden"`
+		Limit   *int          `vee:"hidden"`
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string][]string
+		kind    string
+		message string
+	}{
+		{"bool", map[string][]string{"active": {"on"}}, KindBool, "Must be true or false"},
+		{"slice element", map[string][]string{"items": {"1", "256"}}, KindInteger, "Must be at most 255"},
+		{"time", map[string][]string{"created": {"2024-03-15T14:30"}}, KindTime, "Must be a valid date or time"},
+		{"duration", map[string][]string{"timeout": {"5s"}}, KindDuration, "Must be a valid duration"},
+		{"pointer", map[string][]string{"limit": {"many"}}, KindInteger, "Must be a whole number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := 7
+			state := State{Active: true, Items: []uint8{9}, Limit: &limit}
+			err := Bind(tt.values, &state)
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Kind != tt.kind {
+				t.Fatalf("Bind() error = %v, want %s FieldError", err, tt.kind)
+			}
+			if msg := fieldErr.Message(); msg != tt.message {
+				t.Errorf("Message() = %q, want %q", msg, tt.message)
+			}
+			if !state.Active || len(state.Items) != 1 || *state.Limit != 7 {
+				t.Errorf("Bind() = %+v, want fields unchanged", state)
+			}
+		})
+	}
+
+	// Empty values set pointers to nil
+	limit := 7
+	state := State{Limit: &limit}
+	if err := Bind(map[string][]string{"limit": {""}}, &state); err != nil || state.Limit != nil {
+		t.Errorf("Bind() = (%v, %v), want nil Limit", err, state.Limit)
+	}
+}
@@ -0,0 +1,147 @@
+package vee
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInputTypesRendering(t *testing.T) {
+	type Form struct {
+		Query  string    `vee:"type:'search'"`
+		Color  string    `vee:"type:'color'"`
+		Volume int       `vee:"type:'range',min:0,max:11,output"`
+		Ratio  float64   `vee:"type:'range',max:1,step:0.1"`
+		Zip    uint      `vee:"type:'text',min:1000"`
+		Weight float32   `vee:"type:'text'"`
+		Month  time.Time `vee:"type:'month'"`
+		Week   time.Time `vee:"type:'week'"`
+		Tags   string    `vee:"type:'unknown'"`
+	}
+
+	form := Form{
+		Query:  "vee",
+		Color:  "#00ff7f",
+		Volume: 7,
+		Ratio:  0.5,
+		Zip:    10115,
+		Weight: 1.5,
+		Month:  time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+		Week:   time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}
+	got, err := Render(form)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := []string{
+		`<input type="search" name="query" value="vee" id="query">`,
+		`<input type="color" name="color" value="#00ff7f" id="color">`,
+		`<input type="range" name="volume" value="7" min="0" max="11" id="volume" oninput="this.nextElementSibling.value=this.value">
+<output for="volume" id="volume_output">7</output>`,
+		`<input type="range" name="ratio" value="0.5" max="1" step="0.1" id="ratio">`,
+		`<input type="text" name="zip" value="10115" inputmode="numeric" id="zip">`,
+		`<input type="text" name="weight" value="1.5" inputmode="decimal" id="weight">`,
+		`<input type="month" name="month" value="2024-03" id="month">`,
+		`<input type="week" name="week" value="2025-W01" id="week">`,
+		`<input type="text" name="tags" value="" id="tags">`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, got)
+		}
+	}
+}
+
+func TestInputTypesBinding(t *testing.T) {
+	type Form struct {
+		Color  string     `vee:"type:'color'"`
+		Volume int        `vee:"type:'range'"`
+		Zip    uint       `vee:"type:'text'"`
+		Month  time.Time  `vee:"type:'month'"`
+		Week   *time.Time `vee:"type:'week'"`
+	}
+
+	var form Form
+	err := Bind(map[string][]string{
+		"color":  {"#00FF7F"},
+		"volume": {"7"},
+		"zip":    {"10115"},
+		"month":  {"2024-03"},
+		"week":   {"2025-W01"},
+	}, &form)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	if form.Color != "#00ff7f" || form.Volume != 7 || form.Zip != 10115 {
+		t.Errorf("Bind() = %+v, want color, range and text values", form)
+	}
+	if want := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC); !form.Month.Equal(want) {
+		t.Errorf("Bind() Month = %v, want %v", form.Month, want)
+	}
+	if want := time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC); form.Week == nil || !form.Week.Equal(want) {
+		t.Errorf("Bind() Week = %v, want Monday %v", form.Week, want)
+	}
+
+	// Values round-trip through Render
+	html, err := Render(form)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, exp := range []string{`value="#00ff7f"`, `value="2024-03"`, `value="2025-W01"`} {
+		if !strings.Contains(html, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, html)
+		}
+	}
+}
+
+func TestInputTypesBindingErrors(t *testing.T) {
+	type Form struct {
+		Color string    `vee:"type:'color'"`
+		Week  time.Time `vee:"type:'week'"`
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		kind    string
+		message string
+	}{
+		{"color", "red", KindColor, "Must be a color like #ff0000"},
+		{"color", "#12345g", KindColor, "Must be a color like #ff0000"},
+		{"week", "2024-05", KindTime, "Must be a valid date or time"},
+		{"week", "2021-W53", KindTime, "Must be a valid date or time"},
+		{"week", "2024-W00", KindTime, "Must be a valid date or time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"="+tt.value, func(t *testing.T) {
+			var form Form
+			err := Bind(map[string][]string{tt.name: {tt.value}}, &form)
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Kind != tt.kind || fieldErr.Message() != tt.message {
+				t.Errorf("Bind() error = %v, want %s error", err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestParseWeek(t *testing.T) {
+	tests := map[string]time.Time{
+		"2020-W53": time.Date(2020, time.December, 28, 0, 0, 0, 0, time.UTC),
+		"2021-W01": time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC),
+		"2026-W01": time.Date(2025, time.December, 29, 0, 0, 0, 0, time.UTC),
+	}
+	for value, want := range tests {
+		got, err := parseWeek(value)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseWeek(%q) = (%v, %v), want %v", value, got, err, want)
+		}
+		if formatted := (timeInput{typ: "week"}).format(got, nil); formatted != value {
+			t.Errorf("format(%v) = %q, want %q", got, formatted, value)
+		}
+	}
+}
@@ -40,7 +40,7 @@ func TestLabelGeneration(t *testing.T) {
 <label for="created_at">Created At</label>
 <input type="datetime-local" name="created_at" value="2023-12-25T15:30" id="created_at">
 <label for="timeout">Timeout</label>
-<input type="number" name="timeout" value="30" id="timeout">
+<input type="number" name="timeout" value="30" step="any" id="timeout">
 </form>
 `,
 		},
@@ -73,7 +73,7 @@ func TestLabelGeneration(t *testing.T) {
 <label for="created_at">Created At</label>
 <input type="datetime-local" name="created_at" value="2023-12-25T15:30" id="created_at">
 <label for="timeout">Timeout</label>
-<input type="number" name="timeout" value="30" id="timeout">
+<input type="number" name="timeout" value="30" step="any" id="timeout">
 </form>
 `,
 		},
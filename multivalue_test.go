@@ -82,28 +82,28 @@ func TestMultiValueConventions(t *testing.T) {
 			errorMsg:  "field 'ColorChoices' must be a slice type, got string",
 		},
 		{
-			name: "error: invalid Chosen type for single select (not int)",
+			name: "error: invalid Chosen type for single select",
 			input: struct {
 				ColorChoices []string
-				ColorChosen  string
+				ColorChosen  float64
 			}{
 				ColorChoices: []string{"Red", "Blue"},
-				ColorChosen:  "Red",
+				ColorChosen:  1,
 			},
 			wantError: true,
-			errorMsg:  "field 'ColorChosen' must be int or []int, got string",
+			errorMsg:  "field 'ColorChosen' must be an index (int), a key (string) or a choice (string), a pointer to one of them or a slice of them, got float64",
 		},
 		{
-			name: "error: invalid Chosen type for multi-select (not []int)",
+			name: "error: invalid Chosen type for multi-select",
 			input: struct {
 				ColorChoices []string
-				ColorChosen  []string
+				ColorChosen  []float64
 			}{
 				ColorChoices: []string{"Red", "Blue"},
-				ColorChosen:  []string{"Red"},
+				ColorChosen:  []float64{1},
 			},
 			wantError: true,
-			errorMsg:  "field 'ColorChosen' must be int or []int, got []string",
+			errorMsg:  "field 'ColorChosen' must be an index (int), a key (string) or a choice (string), a pointer to one of them or a slice of them, got []float64",
 		},
 		{
 			name: "error: empty Choices slice",
@@ -0,0 +1,235 @@
+package vee
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type nestedAddress struct {
+	Street string `vee:"required"`
+	City   string
+}
+
+type nestedContact struct {
+	Email   string `vee:"type:'email'"`
+	Address *nestedAddress
+}
+
+type nestedUser struct {
+	Name     string
+	Home     nestedAddress `vee:"label:'Home Address'" css:"group" labelCss:"legend"`
+	Contact  *nestedContact
+	Location *nestedAddress `vee:"$loc,nolabel"`
+}
+
+func TestNestedRendering(t *testing.T) {
+	user := nestedUser{
+		Name:    "John",
+		Home:    nestedAddress{Street: "Main St 1", City: "Springfield"},
+		Contact: &nestedContact{Email: "john@example.com"},
+	}
+
+	got, err := Render(user, InputCSSOption("input"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<form method="POST">
+<label for="name">Name</label>
+<input type="text" name="name" value="John" class="input" id="name">
+<fieldset id="home" class="group"><legend class="legend">Home Address</legend>
+<label for="home.street">Street</label>
+<input type="text" name="home.street" value="Main St 1" class="input" id="home.street" required>
+<label for="home.city">City</label>
+<input type="text" name="home.city" value="Springfield" class="input" id="home.city">
+</fieldset>
+<fieldset id="contact"><legend>Contact</legend>
+<label for="contact.email">Email</label>
+<input type="email" name="contact.email" value="john@example.com" class="input" id="contact.email">
+<fieldset id="contact.address"><legend>Address</legend>
+<label for="contact.address.street">Street</label>
+<input type="text" name="contact.address.street" value="" class="input" id="contact.address.street" required>
+<label for="contact.address.city">City</label>
+<input type="text" name="contact.address.city" value="" class="input" id="contact.address.city">
+</fieldset>
+</fieldset>
+<fieldset id="loc">
+<label for="loc.street">Street</label>
+<input type="text" name="loc.street" value="" class="input" id="loc.street" required>
+<label for="loc.city">City</label>
+<input type="text" name="loc.city" value="" class="input" id="loc.city">
+</fieldset>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestNestedNameSeparator(t *testing.T) {
+	type Form struct {
+		Home nestedAddress
+	}
+
+	html, err := Render(Form{}, NameSeparatorOption("_"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(html, `<input type="text" name="home_street" value="" id="home_street" required>`) {
+		t.Errorf("Render() = %s, want underscore separated names", html)
+	}
+
+	var form Form
+	err = Bind(map[string][]string{"home_street": {"Elm St"}, "home.city": {"Ignored"}}, &form, BindNameSeparatorOption("_"))
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if form.Home.Street != "Elm St" || form.Home.City != "" {
+		t.Errorf("Bind() = %+v, want Street 'Elm St' only", form.Home)
+	}
+}
+
+func TestNestedBinding(t *testing.T) {
+	t.Run("binds nested fields", func(t *testing.T) {
+		var user nestedUser
+		err := Bind(map[string][]string{
+			"name":                   {"John"},
+			"home.street":            {"Main St 1"},
+			"home.city":              {"Springfield"},
+			"contact.address.street": {"Elm St 2"},
+		}, &user)
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+
+		if user.Name != "John" || user.Home.Street != "Main St 1" || user.Home.City != "Springfield" {
+			t.Errorf("Bind() = %+v, want top-level and Home fields bound", user)
+		}
+		if user.Contact == nil || user.Contact.Address == nil || user.Contact.Address.Street != "Elm St 2" {
+			t.Fatalf("Bind() Contact = %+v, want allocated Contact.Address", user.Contact)
+		}
+		if user.Location != nil {
+			t.Errorf("Bind() Location = %+v, want nil without submitted fields", user.Location)
+		}
+	})
+
+	t.Run("keeps existing pointers", func(t *testing.T) {
+		address := &nestedAddress{Street: "Old St", City: "Oldtown"}
+		user := nestedUser{Location: address}
+		if err := Bind(map[string][]string{"loc.city": {"Newtown"}}, &user); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if user.Location != address || address.Street != "Old St" || address.City != "Newtown" {
+			t.Errorf("Bind() Location = %+v, want existing struct updated", user.Location)
+		}
+	})
+
+	t.Run("errors use field paths", func(t *testing.T) {
+		type Form struct {
+			Stats struct {
+				Count int
+			}
+		}
+		var form Form
+		err := Bind(map[string][]string{"stats.count": {"many"}}, &form)
+
+		var bindErrs BindErrors
+		if !errors.As(err, &bindErrs) || len(bindErrs) != 1 {
+			t.Fatalf("Bind() error = %v, want one BindErrors entry", err)
+		}
+		if bindErrs[0].Field != "Stats.Count" || bindErrs[0].Name != "stats.count" {
+			t.Errorf("FieldError = %+v, want Field 'Stats.Count' and Name 'stats.count'", bindErrs[0])
+		}
+	})
+}
+
+func TestNestedErrorsAndFields(t *testing.T) {
+	type Form struct {
+		Home nestedAddress `vee:"help:'Where you live'"`
+	}
+
+	errs := make(FieldErrors)
+	errs.Add("Home.Street", "Required")
+	errs.Add("Home", "Incomplete address")
+
+	got, err := RenderField(Form{}, "Home.Street", RenderOption{Errors: errs})
+	if err != nil {
+		t.Fatalf("RenderField() error = %v", err)
+	}
+	want := `<label for="home.street">Street</label>
+<input type="text" name="home.street" value="" class="error" id="home.street" required aria-invalid="true" aria-describedby="home.street_error">
+<span id="home.street_error">Required</span>
+`
+	if got != want {
+		t.Errorf("RenderField() = %q, want %q", got, want)
+	}
+
+	got, err = RenderField(Form{}, "Home", RenderOption{Errors: errs})
+	if err != nil {
+		t.Fatalf("RenderField() error = %v", err)
+	}
+	if !strings.HasPrefix(got, `<fieldset id="home" aria-describedby="home_help home_error"><legend>Home</legend>`) ||
+		!strings.HasSuffix(got, "</fieldset>\n<small id=\"home_help\">Where you live</small>\n<span id=\"home_error\">Incomplete address</span>\n") {
+		t.Errorf("RenderField() = %q, want fieldset with help and errors", got)
+	}
+
+	if _, err := RenderField(Form{}, "Home.Missing"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("RenderField() error = %v, want ErrFieldNotFound", err)
+	}
+}
+
+type nestedNode struct {
+	Name     string
+	Next     *nestedNode
+	Children *nestedTree
+}
+
+type nestedTree struct {
+	Root *nestedNode
+}
+
+func TestNestedRecursiveTypes(t *testing.T) {
+	got, err := Render(nestedNode{Name: "root"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := `<form method="POST">
+<label for="name">Name</label>
+<input type="text" name="name" value="root" id="name">
+<fieldset id="children"><legend>Children</legend>
+</fieldset>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	var node nestedNode
+	if err := Bind(map[string][]string{"next.next.name": {"third"}}, &node); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if node.Next == nil || node.Next.Next == nil || node.Next.Next.Name != "third" || node.Children != nil {
+		t.Errorf("Bind() = %+v, want Next.Next.Name 'third'", node)
+	}
+}
+
+func TestNestedStructuralErrors(t *testing.T) {
+	type Inner struct {
+		ColorChosen int
+	}
+	type Outer struct {
+		Name  string
+		Inner *Inner
+	}
+
+	if _, err := Render(Outer{}); !errors.Is(err, ErrChoicesChosen) {
+		t.Errorf("Render() error = %v, want ErrChoicesChosen", err)
+	}
+
+	// Reported before any field is bound
+	var outer Outer
+	if err := Bind(map[string][]string{"name": {"John"}}, &outer); !errors.Is(err, ErrChoicesChosen) || outer.Name != "" {
+		t.Errorf("Bind() = (%v, %+v), want ErrChoicesChosen and no bound fields", err, outer)
+	}
+}
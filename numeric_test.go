@@ -1,6 +1,9 @@
 package vee
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -259,3 +262,113 @@ func TestNumericBinding(t *testing.T) {
 		})
 	}
 }
+
+func TestNumericKinds(t *testing.T) {
+	type Level uint8
+	type Form struct {
+		Small   int8
+		Medium  int32
+		Count   uint
+		Level   Level
+		Port    *uint16 `vee:"min:1024"`
+		Ratio   float32
+		Offset  *int16
+		Percent *Level
+	}
+
+	form := Form{Small: -5, Medium: 70000, Count: 3, Level: 7, Ratio: 0.1}
+	got, err := Render(form)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := []string{
+		`<input type="number" name="small" value="-5" id="small">`,
+		`<input type="number" name="medium" value="70000" id="medium">`,
+		`<input type="number" name="count" value="3" min="0" id="count">`,
+		`<input type="number" name="level" value="7" min="0" id="level">`,
+		`<input type="number" name="port" value="0" min="1024" id="port">`,
+		`<input type="number" name="ratio" value="0.1" step="any" id="ratio">`,
+		`<input type="number" name="offset" value="0" id="offset">`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, got)
+		}
+	}
+
+	var bound Form
+	err = Bind(map[string][]string{
+		"small":   {"-128"},
+		"medium":  {"2147483647"},
+		"count":   {"18446744073709551615"},
+		"level":   {"255"},
+		"port":    {"8080"},
+		"ratio":   {"0.1"},
+		"offset":  {"-7"},
+		"percent": {"50"},
+	}, &bound)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if bound.Small != -128 || bound.Medium != 2147483647 || bound.Count != 18446744073709551615 || bound.Level != 255 || bound.Ratio != 0.1 {
+		t.Errorf("Bind() = %+v, want limits of each type", bound)
+	}
+	if bound.Port == nil || *bound.Port != 8080 || bound.Offset == nil || *bound.Offset != -7 || bound.Percent == nil || *bound.Percent != 50 {
+		t.Errorf("Bind() pointers = (%v, %v, %v), want (8080, -7, 50)", bound.Port, bound.Offset, bound.Percent)
+	}
+}
+
+func TestNumericRangeErrors(t *testing.T) {
+	type Form struct {
+		Small  int8
+		Big    int64
+		Level  uint8
+		Count  uint
+		Ratio  float32
+		Offset *int16
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"small", "128", "Must be at most 127"},
+		{"small", "-129", "Must be at least -128"},
+		{"big", "9223372036854775808", "Must be at most 9223372036854775807"},
+		{"big", "-9223372036854775809", "Must be at least -9223372036854775808"},
+		{"level", "256", "Must be at most 255"},
+		{"level", "-1", "Must be at least 0"},
+		{"count", "-99999999999999999999", "Must be at least 0"},
+		{"ratio", "1e39", "Must be at most 3.4028235e+38"},
+		{"ratio", "-1e39", "Must be at least -3.4028235e+38"},
+		{"offset", "40000", "Must be at most 32767"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"="+tt.value, func(t *testing.T) {
+			var form Form
+			err := Bind(map[string][]string{tt.name: {tt.value}}, &form)
+
+			var rangeErr *RangeError
+			if !errors.As(err, &rangeErr) || !errors.Is(err, strconv.ErrRange) {
+				t.Fatalf("Bind() error = %v, want RangeError wrapping strconv.ErrRange", err)
+			}
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Message() != tt.want {
+				t.Errorf("Message() = %q, want %q", fieldErr.Message(), tt.want)
+			}
+			if form != (Form{}) {
+				t.Errorf("Bind() = %+v, want unchanged", form)
+			}
+		})
+	}
+
+	var form Form
+	err := Bind(map[string][]string{"level": {"-x"}}, &form)
+	var rangeErr *RangeError
+	if err == nil || errors.As(err, &rangeErr) {
+		t.Errorf("Bind() error = %v, want syntax error", err)
+	}
+}
@@ -232,3 +232,23 @@ func TestOptionConsolidation(t *testing.T) {
 		}
 	}
 }
+
+// classRenderer is an uncomparable Renderer, as the docs suggest writing them
+type classRenderer struct {
+	HTMLRenderer
+	classes map[string]string
+}
+
+func TestIsEqualUncomparableRenderer(t *testing.T) {
+	a := RendererOption(classRenderer{classes: map[string]string{"input": "a"}})
+	b := RendererOption(classRenderer{classes: map[string]string{"input": "b"}})
+	if !a.IsEqual(RendererOption(classRenderer{classes: map[string]string{"input": "a"}})) {
+		t.Errorf("IsEqual() = false, want renderers with the same state to be equal")
+	}
+	if a.IsEqual(b) {
+		t.Errorf("IsEqual() = true, want renderers with different state to differ")
+	}
+	if a.IsEqual(RendererOption(HTMLRenderer{})) || a.IsEqual(RenderOption{}) {
+		t.Errorf("IsEqual() = true, want renderers of different types to differ")
+	}
+}
@@ -139,7 +139,7 @@ func TestPointerRendering(t *testing.T) {
 			}{Timeout: durationPtr(5 * time.Second)},
 			want: `<form method="POST">
 <label for="timeout">Timeout</label>
-<input type="number" name="timeout" value="5" id="timeout">
+<input type="number" name="timeout" value="5" step="any" id="timeout">
 </form>
 `,
 		},
@@ -150,7 +150,7 @@ func TestPointerRendering(t *testing.T) {
 			}{Timeout: nil},
 			want: `<form method="POST">
 <label for="timeout">Timeout</label>
-<input type="number" name="timeout" id="timeout">
+<input type="number" name="timeout" step="any" id="timeout">
 </form>
 `,
 		},
@@ -1,508 +1,844 @@
 package vee
 
 import (
+	"bytes"
+	"cmp"
+	"encoding"
 	"fmt"
+	"io"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 )
 
+// RenderWithErrors renders a form like Render and displays the errors returned
+// by Validate or Bind next to the fields they belong to.
+func RenderWithErrors(v any, errs error, opts ...RenderOption) (string, error) {
+	return Render(v, append(slices.Clip(opts), ErrorsOption(errs))...)
+}
+
 // Render generates HTML form fields from a Go struct.
 // Accepts optional RenderOptions to customize form rendering.
 func Render(v any, opts ...RenderOption) (string, error) {
 	options := ConsolidateOptions(opts...)
-	// if len(opts) > 0 && opts[0] != nil {
-	// 	options = opts[0]
-	// } else {
-	// 	options = &RenderOption{}
-	// }
-	val := reflect.ValueOf(v)
-	typ := reflect.TypeOf(v)
-
-	// Handle pointer to struct
-	if typ.Kind() == reflect.Ptr {
-		val = val.Elem()
-		typ = typ.Elem()
+	fields, err := buildFields(v, options)
+	if err != nil {
+		return "", err
 	}
 
-	if typ.Kind() != reflect.Struct {
-		return "", fmt.Errorf("vee: expected struct, got %v", typ.Kind())
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := renderForm(buf, fields, options, nil); err != nil {
+		return "", err
 	}
+	return buf.String(), nil
+}
 
-	// First pass: validate hidden field restrictions before other validations
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-		if !field.IsExported() {
-			continue
-		}
+// RenderTo renders a form like Render and streams the output to w, writing
+// the opening tag, each field and the closing tag as they are rendered
+// instead of returning the whole form as a string. It allocates about as
+// much as Render.
+func RenderTo(w io.Writer, v any, opts ...RenderOption) error {
+	options := ConsolidateOptions(opts...)
+	fields, err := buildFields(v, options)
+	if err != nil {
+		return err
+	}
 
-		veeTag := field.Tag.Get("vee")
-		config := parseVeeTag(veeTag, field.Name)
+	buf := getBuffer()
+	defer putBuffer(buf)
 
-		// Skip if requested
-		if config.Skip {
-			continue
-		}
+	return renderForm(buf, fields, options, func() error {
+		_, err := w.Write(buf.Bytes())
+		buf.Reset()
+		return err
+	})
+}
 
-		// Validate hidden field restrictions
-		if config.Hidden {
-			// Check if this is a pointer type
-			if field.Type.Kind() == reflect.Ptr {
-				return "", fmt.Errorf("vee: hidden attribute not supported for pointer type '%s'", field.Name)
-			}
+// renderForm writes the form wrapper and all fields to buf, calling flush
+// (if not nil) after the opening tag, each field and the closing tag
+func renderForm(buf *bytes.Buffer, fields []Field, options *RenderOption, flush func() error) error {
+	renderer := options.renderer()
+	if flush == nil {
+		flush = func() error { return nil }
+	}
 
-			// Check if this is a multi-value field (Choices or Chosen)
-			if strings.HasSuffix(field.Name, "Choices") || strings.HasSuffix(field.Name, "Chosen") {
-				return "", fmt.Errorf("vee: hidden attribute not supported for multi-value field '%s'", field.Name)
-			}
+	// Always wrap in form tag
+	form := buildForm(options, fields)
+	if err := renderer.FormOpen(buf, form); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
 
-			// Check if field type is a slice/array
-			if field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array {
-				return "", fmt.Errorf("vee: hidden attribute not supported for slice/array type '%s'", field.Name)
-			}
+	for i := range fields {
+		if err := writeField(renderer, buf, &fields[i]); err != nil {
+			return err
 		}
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	// Always close form tag
+	if err := renderer.FormClose(buf, form); err != nil {
+		return err
 	}
+	return flush()
+}
 
-	// Validate Choices/Chosen pairs
-	choicesChosenPairs, err := validateChoicesChosen(typ, val)
+// RenderFields renders all fields like Render but without the <form> wrapper,
+// for use inside an existing form.
+func RenderFields(v any, opts ...RenderOption) (string, error) {
+	options := ConsolidateOptions(opts...)
+	fields, err := buildFields(v, options)
 	if err != nil {
 		return "", err
 	}
 
-	var html strings.Builder
+	buf := getBuffer()
+	defer putBuffer(buf)
 
-	// Always wrap in form tag
-	html.WriteString("<form")
-	if options.FormID != "" {
-		html.WriteString(fmt.Sprintf(` id="%s"`, escapeHTML(options.FormID)))
-	}
-	if options.FormCSS != "" {
-		html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(options.FormCSS)))
-	}
-	// Skip method and action if we're going to submit the form via Javascript
-	if options.FormAction != scriptAction {
-		method := options.FormMethod
-		if method == "" {
-			method = "POST"
-		}
-		html.WriteString(fmt.Sprintf(` method="%s"`, method))
-		if options.FormAction != "" {
-			html.WriteString(fmt.Sprintf(` action="%s"`, escapeHTML(options.FormAction)))
+	renderer := options.renderer()
+	for i := range fields {
+		if err := writeField(renderer, buf, &fields[i]); err != nil {
+			return "", err
 		}
 	}
-	html.WriteString(">\n")
+	return buf.String(), nil
+}
 
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-		fieldVal := val.Field(i)
+// RenderField renders the label, input, help text and errors of the struct
+// field with the given Go name.
+func RenderField(v any, name string, opts ...RenderOption) (string, error) {
+	return renderNamedField(v, name, opts, writeField)
+}
 
-		// Skip unexported fields
-		if !field.IsExported() {
-			continue
-		}
+// RenderLabel renders only the label of the struct field with the given Go name.
+// Fields without a label render as an empty string.
+func RenderLabel(v any, name string, opts ...RenderOption) (string, error) {
+	return renderNamedField(v, name, opts, writeLabel)
+}
 
-		// Parse vee tag
-		veeTag := field.Tag.Get("vee")
-		config := parseVeeTag(veeTag, field.Name)
+// RenderInput renders only the input of the struct field with the given Go name.
+// Radio and checkbox groups include their fieldset and legend.
+func RenderInput(v any, name string, opts ...RenderOption) (string, error) {
+	return renderNamedField(v, name, opts, writeControl)
+}
 
-		// Skip if requested
-		if config.Skip {
-			continue
-		}
+// RenderErrors renders only the error messages of the struct field with the
+// given Go name. Fields without errors render as an empty string.
+func RenderErrors(v any, name string, opts ...RenderOption) (string, error) {
+	return renderNamedField(v, name, opts, writeErrors)
+}
 
-		// Build CSS classes
-		var cssClass string
-		cssTag := field.Tag.Get("css")
-		if cssTag != "" {
-			cssClass = cssTag
-		} else if options.DefaultInputCSS != "" {
-			cssClass = options.DefaultInputCSS
-		}
+// renderNamedField renders part of a single field selected by its Go name
+func renderNamedField(v any, name string, opts []RenderOption, write func(Renderer, io.Writer, *Field) error) (string, error) {
+	options := ConsolidateOptions(opts...)
+	fields, err := buildFields(v, options)
+	if err != nil {
+		return "", err
+	}
 
-		var labelCssClass string
-		labelCssTag := field.Tag.Get("labelCss")
-		if labelCssTag != "" {
-			labelCssClass = labelCssTag
-		} else if options.DefaultLabelCSS != "" {
-			labelCssClass = options.DefaultLabelCSS
-		}
+	f := findField(fields, name)
+	if f == nil {
+		return "", fmt.Errorf("%w: '%s'", ErrFieldNotFound, name)
+	}
 
-		// Skip Choices fields (they're not rendered, only used for Chosen fields)
-		if strings.HasSuffix(field.Name, "Choices") {
-			continue
-		}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := write(options.renderer(), buf, f); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
 
-		// Handle Chosen fields specially
-		if strings.HasSuffix(field.Name, "Chosen") {
-			baseName := strings.TrimSuffix(field.Name, "Chosen")
-			if pair, exists := choicesChosenPairs[baseName]; exists {
-				err := renderMultiValueField(&html, pair, config, cssClass, labelCssClass)
-				if err != nil {
-					return "", err
-				}
-				continue
-			}
+// findField returns the field with the given Go field path, searching the
+// fields of nested structs
+func findField(fields []Field, path string) *Field {
+	for i := range fields {
+		f := &fields[i]
+		if f.StructField == path {
+			return f
 		}
-
-		// Handle hidden fields early - they override normal rendering
-		if config.Hidden {
-			err := renderHiddenField(&html, field, fieldVal, config)
-			if err != nil {
-				return "", err
-			}
+		if f.control != controlFieldset || !strings.HasPrefix(path, f.StructField) {
 			continue
 		}
+		// Search the fields of nested structs (Address.Street) and slice rows (Items[0].SKU)
+		if rest := path[len(f.StructField):]; rest[0] == '.' || rest[0] == '[' {
+			return findField(f.Fields, path)
+		}
+	}
+	return nil
+}
 
-		// Handle pointer types
-		actualType := field.Type
-		actualVal := fieldVal
-		isPointer := false
+// bufferPool holds buffers reused across render calls
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
 
-		if actualType.Kind() == reflect.Ptr {
-			isPointer = true
-			actualType = actualType.Elem()
+// maxPooledBufferSize limits the size of buffers returned to the pool so
+// that rendering one very large form does not pin its memory
+const maxPooledBufferSize = 64 * 1024
 
-			// If pointer is nil, we'll use zero values for rendering
-			if fieldVal.IsNil() {
-				actualVal = reflect.Zero(actualType)
-			} else {
-				actualVal = fieldVal.Elem()
-			}
-		}
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
 
-		// Check for specific types first (before generic kind matching)
-		if actualType == reflect.TypeOf(time.Time{}) {
-			timeVal := actualVal.Interface().(time.Time)
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
 
-			// Render label first
-			renderLabel(&html, config, field.Name, labelCssClass)
+// buildFields validates the struct v and resolves its renderable fields
+func buildFields(v any, options *RenderOption) ([]Field, error) {
+	val, schema, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return buildStructFields(val, schema, options, fieldScope{sep: options.nameSeparator()}, nil)
+}
 
-			// Determine input type (default to datetime-local)
-			inputType := "datetime-local"
-			if typeAttr, ok := config.Attributes["type"]; ok {
-				switch typeAttr {
-				case "date", "datetime-local", "time":
-					inputType = typeAttr
-				}
-			}
+// buildStructFields resolves the renderable fields of a struct value. parents
+// holds the types of the enclosing structs to stop at recursive types.
+func buildStructFields(val reflect.Value, schema *typeSchema, options *RenderOption, scope fieldScope, parents []reflect.Type) ([]Field, error) {
+	if schema.renderErr != nil {
+		return nil, schema.renderErr
+	}
+	if schema.err != nil {
+		return nil, schema.err
+	}
 
-			html.WriteString(fmt.Sprintf(`<input type="%s"`, inputType))
-			html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-
-			// Format the value based on input type
-			var value string
-			if !isPointer || !fieldVal.IsNil() {
-				if !timeVal.IsZero() {
-					switch inputType {
-					case "date":
-						value = timeVal.Format("2006-01-02")
-					case "time":
-						value = timeVal.Format("15:04")
-					case "datetime-local":
-						value = timeVal.Format("2006-01-02T15:04")
-					}
-					html.WriteString(fmt.Sprintf(` value="%s"`, escapeHTML(value)))
-				}
-			}
+	// Provide and validate Choices/Chosen values
+	pairs, err := schema.choicePairs(options.context(), val, schema.validatedPairs(scope, options.choicesField))
+	if err != nil {
+		return nil, err
+	}
 
-			// Add min/max attributes
-			if min, ok := config.Attributes["min"]; ok {
-				html.WriteString(fmt.Sprintf(` min="%s"`, escapeHTML(min)))
-			}
-			if max, ok := config.Attributes["max"]; ok {
-				html.WriteString(fmt.Sprintf(` max="%s"`, escapeHTML(max)))
-			}
+	parents = append(parents, schema.typ)
+	fields := make([]Field, 0, len(schema.fields))
+	for i := range schema.fields {
+		fs := &schema.fields[i]
 
-			// Add CSS class
-			if cssClass != "" {
-				html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
-			}
+		// Skip Choices fields (they're not rendered, only used for Chosen fields)
+		if fs.kind == kindChoices {
+			continue
+		}
 
-			// Add universal attributes
-			addUniversalAttributes(&html, config)
+		var f Field
+		initField(&f, fs, options, scope)
 
-			html.WriteString(">\n")
+		// Handle Chosen fields specially
+		var fieldErr error
+		var ok bool
+		fieldVal := fs.value(val)
+		switch {
+		case fs.kind == kindChosen && fs.config.Hidden:
+			fieldErr = buildHiddenChosen(&f, pairs[fs.pair])
+			ok = true
+		case fs.kind == kindChosen:
+			ok, fieldErr = buildMultiValueField(&f, pairs[fs.pair], fs.config, scope)
+		case fs.config.Hidden:
+			// Hidden fields override normal rendering
+			ok, fieldErr = buildHiddenField(&f, fs, fieldVal)
+		case fs.kind == kindStruct:
+			ok, fieldErr = buildFieldset(&f, fs, fieldVal, options, scope, parents)
+		case fs.kind == kindStructSlice:
+			ok, fieldErr = buildRows(&f, fs, fieldVal, options, parents)
+		default:
+			ok, fieldErr = buildInputField(&f, fs, fieldVal, options)
+		}
+		if fieldErr != nil {
+			return nil, fieldErr
+		}
+		if !ok {
+			// Skip unsupported types
 			continue
 		}
 
-		if actualType == reflect.TypeOf(time.Duration(0)) {
-			durationVal := actualVal.Interface().(time.Duration)
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
 
-			// Render label first
-			renderLabel(&html, config, field.Name, labelCssClass)
+// buildFieldset fills in a Field for a nested struct rendered as a fieldset
+// and reports whether the struct type is supported
+func buildFieldset(f *Field, fs *fieldSchema, fieldVal reflect.Value, options *RenderOption, scope fieldScope, parents []reflect.Type) (bool, error) {
+	structVal := fieldVal
+	if fs.pointer {
+		// Nil pointers render the fields with zero values
+		if fieldVal.IsNil() {
+			structVal = reflect.Zero(fieldVal.Type().Elem())
+		} else {
+			structVal = fieldVal.Elem()
+		}
+	}
 
-			// Get units (default to seconds)
-			units := "s"
-			if unitsAttr, ok := config.Attributes["units"]; ok {
-				switch unitsAttr {
-				case "ms", "s", "m", "h":
-					units = unitsAttr
-				}
-			}
+	// Recursive types would render endlessly
+	if slices.Contains(parents, fs.elem) {
+		return false, nil
+	}
 
-			html.WriteString(`<input type="number"`)
-			html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-
-			// Convert duration to specified units and render value
-			if (!isPointer || !fieldVal.IsNil()) && durationVal != 0 {
-				var value float64
-				switch units {
-				case "ms":
-					value = float64(durationVal / time.Millisecond)
-				case "s":
-					value = float64(durationVal / time.Second)
-				case "m":
-					value = float64(durationVal / time.Minute)
-				case "h":
-					value = float64(durationVal / time.Hour)
-				}
-				html.WriteString(fmt.Sprintf(` value="%g"`, value))
-			}
+	f.control = controlFieldset
+	f.Type = "fieldset"
 
-			// Add numeric attributes
-			if min, ok := config.Attributes["min"]; ok {
-				html.WriteString(fmt.Sprintf(` min="%s"`, escapeHTML(min)))
-			}
-			if max, ok := config.Attributes["max"]; ok {
-				html.WriteString(fmt.Sprintf(` max="%s"`, escapeHTML(max)))
-			}
-			if step, ok := config.Attributes["step"]; ok {
-				html.WriteString(fmt.Sprintf(` step="%s"`, escapeHTML(step)))
-			}
+	// The default input CSS does not apply to the fieldset itself
+	f.CSS = fs.css
 
-			// Add CSS class
-			if cssClass != "" {
-				html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
-			}
+	var err error
+	nested := scope.nest(f.Name, f.ID, f.StructField)
+	f.Fields, err = buildStructFields(structVal, schemaFor(fs.elem), options, nested, parents)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-			// Add universal attributes
-			addUniversalAttributes(&html, config)
+// TemplateIndex is the row index used in the names and ids of the row template
+// of a slice. Client code replaces it with the index of the added row.
+const TemplateIndex = "__index__"
 
-			html.WriteString(">\n")
-			continue
-		}
+// buildRows fills in a Field for a slice of structs rendered as a fieldset
+// with a nested fieldset per row and a row template
+func buildRows(f *Field, fs *fieldSchema, fieldVal reflect.Value, options *RenderOption, parents []reflect.Type) (bool, error) {
+	// Recursive types would render endlessly
+	if slices.Contains(parents, fs.elem) {
+		return false, nil
+	}
 
-		// Render field based on type
-		switch actualType.Kind() {
-		case reflect.String:
-			value := actualVal.String()
+	f.control = controlFieldset
+	f.Type = "rows"
+	f.CSS = fs.css
+	if fs.minRows > 0 {
+		f.Attrs = append(f.Attrs, Attr{Name: "data-min-rows", Value: strconv.Itoa(fs.minRows)})
+	}
+	if fs.maxRows > 0 {
+		f.Attrs = append(f.Attrs, Attr{Name: "data-max-rows", Value: strconv.Itoa(fs.maxRows)})
+	}
 
-			// Render label first
-			renderLabel(&html, config, field.Name, labelCssClass)
+	// The presence input lets Bind tell a form without rows from one
+	// without the field
+	f.Presence = &Field{
+		StructField: f.StructField,
+		Name:        f.Name,
+		ID:          f.ID + "_rows",
+		Type:        "hidden",
+		HasValue:    true,
+		control:     controlHidden,
+	}
 
-			// Determine input type (default to text, but allow override)
-			inputType := "text"
-			if typeAttr, ok := config.Attributes["type"]; ok {
-				switch typeAttr {
-				case "email", "password", "tel", "url":
-					inputType = typeAttr
+	// Render at least the minimum number of rows, padded with empty rows
+	rows := max(fieldVal.Len(), fs.minRows)
+	f.Fields = make([]Field, rows)
+	for i := range rows {
+		rowVal := reflect.Zero(fs.elem)
+		zero := i >= fieldVal.Len()
+		if !zero {
+			rowVal = fieldVal.Index(i)
+			if fs.elemPointer {
+				// Nil rows render with zero values
+				if rowVal.IsNil() {
+					rowVal = reflect.Zero(fs.elem)
+				} else {
+					rowVal = rowVal.Elem()
 				}
 			}
+		}
+		if err := buildRow(&f.Fields[i], f, rowVal, options, strconv.Itoa(i), zero, parents); err != nil {
+			return false, err
+		}
+	}
 
-			html.WriteString(fmt.Sprintf(`<input type="%s"`, inputType))
-			html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-			html.WriteString(fmt.Sprintf(` value="%s"`, escapeHTML(value)))
+	f.Template = &Field{}
+	if err := buildRow(f.Template, f, reflect.Zero(fs.elem), options, TemplateIndex, true, parents); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-			// Add CSS class
-			if cssClass != "" {
-				html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
-			}
+// buildRow fills in a Field for a single row of a slice of structs
+func buildRow(row *Field, f *Field, rowVal reflect.Value, options *RenderOption, index string, zero bool, parents []reflect.Type) error {
+	scope := fieldScope{sep: options.nameSeparator()}.row(f.Name, f.ID, f.StructField, index, zero)
+
+	row.control = controlFieldset
+	row.Type = "row"
+	row.StructField = scope.field
+	row.Name = scope.name
+	row.ID = scope.id
+	row.NoLabel = true
+	row.Errors = options.Errors[row.StructField]
+	row.ErrorCSS = options.ErrorMessageCSS
+
+	var err error
+	row.Fields, err = buildStructFields(rowVal, schemaFor(rowVal.Type()), options, scope, parents)
+	return err
+}
 
-			// Add universal attributes
-			addUniversalAttributes(&html, config)
+// buildForm resolves the form wrapper attributes from the render options and
+// the fields of the form
+func buildForm(options *RenderOption, fields []Field) *Form {
+	form := &Form{
+		ID:  options.FormID,
+		CSS: options.FormCSS,
+	}
+	if hasFileField(fields) {
+		form.Enctype = "multipart/form-data"
+	}
+	// Skip method and action if we're going to submit the form via Javascript
+	if options.FormAction != scriptAction {
+		form.Method = options.FormMethod
+		if form.Method == "" {
+			form.Method = "POST"
+		}
+		form.Action = options.FormAction
+	}
+	return form
+}
 
-			html.WriteString(">\n")
+// hasFileField reports whether any of the fields, including those of nested
+// structs and row templates, is a file input
+func hasFileField(fields []Field) bool {
+	for i := range fields {
+		f := &fields[i]
+		if f.Type == "file" || hasFileField(f.Fields) || (f.Template != nil && hasFileField(f.Template.Fields)) {
+			return true
+		}
+	}
+	return false
+}
 
-		case reflect.Int, reflect.Int64:
-			value := actualVal.Int()
+// initField fills in the attributes shared by all field types
+func initField(f *Field, fs *fieldSchema, options *RenderOption, scope fieldScope) {
+	f.StructField = scope.fieldPath(fs.field.Name)
+	f.Name = scope.fieldName(fs.config.Name)
+	f.Label = fs.label
+	f.NoLabel = fs.config.NoLabel
+	f.Errors = options.Errors[f.StructField]
+	if fs.embed != "" {
+		// Validate reports promoted fields with the path of their embedded struct
+		f.Errors = append(slices.Clip(f.Errors), options.Errors[scope.fieldPath(fs.embed+fs.field.Name)]...)
+	}
+	f.ErrorCSS = options.ErrorMessageCSS
+	applyUniversalAttributes(f, fs.config)
+	f.ID = scope.fieldID(f.ID)
+
+	// Build CSS classes
+	if fs.css != "" {
+		f.CSS = fs.css
+	} else if options.DefaultInputCSS != "" {
+		f.CSS = options.DefaultInputCSS
+	}
 
-			// Render label first
-			renderLabel(&html, config, field.Name, labelCssClass)
+	// Add error CSS classes if the field has errors
+	if len(f.Errors) > 0 {
+		errorCssClass := defaultErrorCSS
+		if fs.errorCSS != "" {
+			errorCssClass = fs.errorCSS
+		} else if options.DefaultErrorCSS != "" {
+			errorCssClass = options.DefaultErrorCSS
+		}
+		f.CSS = strings.TrimSpace(f.CSS + " " + errorCssClass)
+	}
 
-			html.WriteString(`<input type="number"`)
-			html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-			html.WriteString(fmt.Sprintf(` value="%d"`, value))
+	if fs.labelCSS != "" {
+		f.LabelCSS = fs.labelCSS
+	} else if options.DefaultLabelCSS != "" {
+		f.LabelCSS = options.DefaultLabelCSS
+	}
+}
 
-			// Add numeric attributes
-			if min, ok := config.Attributes["min"]; ok {
-				html.WriteString(fmt.Sprintf(` min="%s"`, escapeHTML(min)))
-			}
-			if max, ok := config.Attributes["max"]; ok {
-				html.WriteString(fmt.Sprintf(` max="%s"`, escapeHTML(max)))
-			}
-			if step, ok := config.Attributes["step"]; ok {
-				html.WriteString(fmt.Sprintf(` step="%s"`, escapeHTML(step)))
-			}
+// applyUniversalAttributes copies the universal attributes (id, placeholder, help,
+// required, readonly, disabled) from the field configuration
+func applyUniversalAttributes(f *Field, config FieldConfig) {
+	f.ID = fieldID(config)
+	f.Placeholder = config.Attributes["placeholder"]
+	f.Help = config.Attributes["help"]
+	_, f.Required = config.Attributes["required"]
+	_, f.Readonly = config.Attributes["readonly"]
+	_, f.Disabled = config.Attributes["disabled"]
+}
 
-			// Add CSS class
-			if cssClass != "" {
-				html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
-			}
+// buildInputField fills in a Field for the basic field types and reports
+// whether the type is supported
+func buildInputField(f *Field, fs *fieldSchema, fieldVal reflect.Value, options *RenderOption) (bool, error) {
+	config := fs.config
 
-			// Add universal attributes
-			addUniversalAttributes(&html, config)
+	// Handle pointer types
+	actualVal := fieldVal
+	isNil := false
 
-			html.WriteString(">\n")
+	if fs.pointer {
+		// If pointer is nil, we'll use zero values for rendering
+		if fieldVal.IsNil() {
+			isNil = true
+			actualVal = reflect.Zero(fieldVal.Type().Elem())
+		} else {
+			actualVal = fieldVal.Elem()
+		}
+	}
 
-		case reflect.Float64:
-			value := actualVal.Float()
+	switch fs.kind {
+	case kindTime:
+		timeVal := actualVal.Interface().(time.Time)
 
-			// Render label first
-			renderLabel(&html, config, field.Name, labelCssClass)
+		// Format the value based on input type
+		f.Type = fs.time.typ
+		if !isNil && !timeVal.IsZero() {
+			f.Value = fs.time.format(timeVal, fieldLocation(fs, options.Location))
+			f.HasValue = true
+		}
 
-			html.WriteString(`<input type="number"`)
-			html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-			html.WriteString(fmt.Sprintf(` value="%g"`, value))
+		// Add min/max/step attributes, which do not apply to text inputs
+		if !fs.time.custom {
+			f.Attrs = tagAttrs(config, "min", "max", "step")
+		}
 
-			// Add numeric attributes (step defaults to "any" for floats if not specified)
-			if min, ok := config.Attributes["min"]; ok {
-				html.WriteString(fmt.Sprintf(` min="%s"`, escapeHTML(min)))
-			}
-			if max, ok := config.Attributes["max"]; ok {
-				html.WriteString(fmt.Sprintf(` max="%s"`, escapeHTML(max)))
-			}
-			if step, ok := config.Attributes["step"]; ok {
-				html.WriteString(fmt.Sprintf(` step="%s"`, escapeHTML(step)))
-			} else {
-				html.WriteString(` step="any"`) // Default for float64
-			}
+	case kindDuration:
+		durationVal := actualVal.Interface().(time.Duration)
+		hasValue := !isNil && durationVal != 0
 
-			// Add CSS class
-			if cssClass != "" {
-				html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
+		if fs.duration.text {
+			f.Type = "text"
+			if hasValue {
+				f.Value = durationVal.String()
+				f.HasValue = true
 			}
+			break
+		}
+
+		// Render the value in the units of the field, or in the largest
+		// unit it is a whole number of if the user can select the unit
+		f.Type = "number"
+		unit := fs.duration.displayUnit(durationVal)
+		if hasValue {
+			f.Value = formatDuration(durationVal, unit)
+			f.HasValue = true
+		}
 
-			// Add universal attributes
-			addUniversalAttributes(&html, config)
+		// Add numeric attributes, allowing fractions of units by default.
+		// Limits in a unit do not apply when the user can change the unit.
+		if fs.duration.selectable {
+			f.Attrs = tagAttrs(config, "step")
+			f.Units = unitsField(f, unit)
+		} else {
+			f.Attrs = tagAttrs(config, "min", "max", "step")
+		}
+		if _, ok := config.Attributes["step"]; !ok {
+			f.Attrs = append(f.Attrs, Attr{Name: "step", Value: "any"})
+		}
 
-			html.WriteString(">\n")
+	case kindString:
+		// Determine input type (default to text, but allow override)
+		f.Type = "text"
+		if typeAttr, ok := config.Attributes["type"]; ok {
+			switch typeAttr {
+			case "email", "password", "tel", "url", "search", "color":
+				f.Type = typeAttr
+			case "textarea":
+				f.control = controlTextarea
+				f.Type = typeAttr
+				f.Attrs = tagAttrs(config, "rows", "cols", "maxlength")
+				if wrap := config.Attributes["wrap"]; wrap == "soft" || wrap == "hard" {
+					f.Attrs = append(f.Attrs, Attr{Name: "wrap", Value: wrap})
+				}
+			}
+		}
+		f.Value = actualVal.String()
+		f.HasValue = true
+
+	case kindInt:
+		f.Value = strconv.FormatInt(actualVal.Int(), 10)
+		f.HasValue = true
+		numberInput(f, config, "numeric")
+
+	case kindUint:
+		f.Value = strconv.FormatUint(actualVal.Uint(), 10)
+		f.HasValue = true
+		numberInput(f, config, "numeric")
+		if _, ok := config.Attributes["min"]; !ok && f.Type != "text" {
+			f.Attrs = append([]Attr{{Name: "min", Value: "0"}}, f.Attrs...) // Unsigned types cannot be negative
+		}
 
-		case reflect.Bool:
-			isChecked := actualVal.Bool()
+	case kindFloat:
+		f.Value = strconv.FormatFloat(actualVal.Float(), 'g', -1, actualVal.Type().Bits())
+		f.HasValue = true
+		numberInput(f, config, "decimal")
+		if _, ok := config.Attributes["step"]; !ok && f.Type != "text" {
+			f.Attrs = append(f.Attrs, Attr{Name: "step", Value: "any"}) // Default for floats
+		}
 
-			// Render label first
-			renderLabel(&html, config, field.Name, labelCssClass)
+	case kindBool:
+		f.control = controlCheckbox
+		f.Type = "checkbox"
+		f.Value = "true"
+		f.HasValue = true
+		f.Checked = actualVal.Bool()
+
+	case kindFile, kindFiles:
+		// File inputs cannot be prefilled
+		f.Type = "file"
+		f.Attrs = tagAttrs(config, "accept", "capture")
+		if fs.kind == kindFiles {
+			f.Attrs = append(f.Attrs, Attr{Name: "multiple"})
+		}
 
-			html.WriteString(`<input type="checkbox"`)
-			html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-			html.WriteString(` value="true"`)
-			if isChecked {
-				html.WriteString(` checked`)
-			}
+	case kindCodec:
+		f.Type = "text"
+		if err := fs.codec.Render(f, actualVal); err != nil {
+			return false, fmt.Errorf("vee: cannot render field '%s': %w", fs.field.Name, err)
+		}
+		if writer, ok := fs.codec.(ControlWriter); ok {
+			f.control = controlCustom
+			f.writer = writer
+		}
 
-			// Add CSS class
-			if cssClass != "" {
-				html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
+	case kindText:
+		f.Type = "text"
+		if !isNil {
+			text, err := marshalText(actualVal)
+			if err != nil {
+				return false, fmt.Errorf("vee: cannot marshal field '%s': %w", fs.field.Name, err)
 			}
+			f.Value = text
+		}
+		f.HasValue = true
 
-			// Add universal attributes
-			addUniversalAttributes(&html, config)
+	default:
+		return false, nil
+	}
 
-			html.WriteString(">\n")
+	return true, nil
+}
 
-		default:
-			// Skip unsupported types
-			continue
-		}
+// marshalText returns the text encoding of a value whose type implements
+// encoding.TextUnmarshaler. Types without MarshalText are formatted with %v.
+func marshalText(val reflect.Value) (string, error) {
+	if !val.Type().Implements(textMarshalerType) && reflect.PointerTo(val.Type()).Implements(textMarshalerType) {
+		// MarshalText has a pointer receiver
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+		val = ptr
 	}
+	if marshaler, ok := val.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	return fmt.Sprint(val.Interface()), nil
+}
 
-	// Always close form tag
-	html.WriteString("</form>\n")
+// numberInput sets the input type and attributes of a numeric field from its
+// type attribute: number (default), range, or text with the given inputmode
+// for numbers that should not be changed by scrolling or spinner buttons
+func numberInput(f *Field, config FieldConfig, inputMode string) {
+	switch config.Attributes["type"] {
+	case "text":
+		f.Type = "text"
+		f.Attrs = []Attr{{Name: "inputmode", Value: inputMode}}
+		return
+	case "range":
+		f.Type = "range"
+		_, f.Output = config.Attributes["output"]
+	default:
+		f.Type = "number"
+	}
+	f.Attrs = tagAttrs(config, "min", "max", "step")
+}
 
-	return html.String(), nil
+// tagAttrs returns the given attributes that are present in the field configuration
+func tagAttrs(config FieldConfig, names ...string) []Attr {
+	var attrs []Attr
+	for _, name := range names {
+		if value, ok := config.Attributes[name]; ok {
+			attrs = append(attrs, Attr{Name: name, Value: value})
+		}
+	}
+	return attrs
 }
 
-// validateChoicesChosen validates Choices/Chosen field pairs and returns information about them
-func validateChoicesChosen(typ reflect.Type, val reflect.Value) (map[string]ChoicesChosenPair, error) {
-	pairs := make(map[string]ChoicesChosenPair)
-	choicesFields := make(map[string]reflect.StructField)
-	chosenFields := make(map[string]reflect.StructField)
+// pairChoicesChosen pairs the Choices and Chosen fields among the fields of a
+// struct type, including promoted and skipped ones, and validates their types
+func pairChoicesChosen(fields []fieldSchema) ([]ChoicesChosenPair, error) {
+	var pairs []ChoicesChosenPair
+	var choicesFields, chosenFields []reflect.StructField
+	byName := make(map[string]reflect.StructField, len(fields))
+
+	configs := make(map[string]FieldConfig, len(fields))
 
 	// First pass: identify Choices and Chosen fields
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-		if !field.IsExported() {
-			continue
-		}
+	for _, fs := range fields {
+		field := fs.field
+		byName[field.Name] = field
+		configs[field.Name] = fs.config
 
-		fieldName := field.Name
-		if strings.HasSuffix(fieldName, "Choices") {
-			baseName := strings.TrimSuffix(fieldName, "Choices")
-			choicesFields[baseName] = field
-		} else if strings.HasSuffix(fieldName, "Chosen") {
-			baseName := strings.TrimSuffix(fieldName, "Chosen")
-			chosenFields[baseName] = field
+		if strings.HasSuffix(field.Name, "Choices") {
+			choicesFields = append(choicesFields, field)
+		} else if strings.HasSuffix(field.Name, "Chosen") {
+			chosenFields = append(chosenFields, field)
 		}
 	}
 
 	// Validate that Choices and Chosen fields come in pairs
-	for baseName, choicesField := range choicesFields {
-		chosenField, hasChosen := chosenFields[baseName]
+	for _, choicesField := range choicesFields {
+		baseName := strings.TrimSuffix(choicesField.Name, "Choices")
+		chosenField, hasChosen := byName[baseName+"Chosen"]
 		if !hasChosen {
-			return nil, fmt.Errorf("vee: field '%s' requires corresponding '%sChosen' field", choicesField.Name, baseName)
+			return nil, structError(ErrChoicesChosen, choicesField.Name, "vee: field '%s' requires corresponding '%sChosen' field", choicesField.Name, baseName)
 		}
 
 		// Validate Choices field type (must be slice)
 		if choicesField.Type.Kind() != reflect.Slice {
-			return nil, fmt.Errorf("vee: field '%s' must be a slice type, got %s", choicesField.Name, choicesField.Type.Kind())
+			return nil, structError(ErrChoicesChosen, choicesField.Name, "vee: field '%s' must be a slice type, got %s", choicesField.Name, choicesField.Type.Kind())
 		}
-
-		// Validate Chosen field type (must be int or []int)
-		chosenKind := chosenField.Type.Kind()
-		isMultiSelect := false
-		if chosenKind == reflect.Slice {
-			if chosenField.Type.Elem().Kind() != reflect.Int {
-				return nil, fmt.Errorf("vee: field '%s' must be int or []int, got %s", chosenField.Name, chosenField.Type)
-			}
+		choiceType := choicesField.Type.Elem()
+
+		// Validate Chosen field type: indices (int), keys (string) or
+		// choices, a pointer to them for optional single selections, or a
+		// slice of them for multi-select
+		chosenType := chosenField.Type
+		isMultiSelect, isPointer := false, false
+		switch {
+		case chosenType == choiceType:
+		case chosenType.Kind() == reflect.Ptr:
+			chosenType = chosenType.Elem()
+			isPointer = true
+		case chosenType.Kind() == reflect.Slice:
+			chosenType = chosenType.Elem()
 			isMultiSelect = true
-		} else if chosenKind != reflect.Int {
-			return nil, fmt.Errorf("vee: field '%s' must be int or []int, got %s", chosenField.Name, chosenField.Type)
 		}
-
-		// Get field values
-		choicesFieldVal := val.FieldByName(choicesField.Name)
-		chosenFieldVal := val.FieldByName(chosenField.Name)
-
-		// Validate choices are not empty
-		if choicesFieldVal.Len() == 0 {
-			return nil, fmt.Errorf("vee: field '%s' cannot be empty", choicesField.Name)
+		var mode chosenMode
+		switch {
+		case chosenType.Kind() == reflect.Int:
+			mode = chosenIndex
+		case chosenType == choiceType:
+			mode = chosenElement
+		case chosenType.Kind() == reflect.String:
+			mode = chosenKey
+		default:
+			return nil, structError(ErrChoicesChosen, chosenField.Name, "vee: field '%s' must be an index (int), a key (string) or a choice (%s), a pointer to one of them or a slice of them, got %s", chosenField.Name, choiceType, chosenField.Type)
 		}
 
-		// Validate chosen indices are in range
-		if isMultiSelect {
-			for i := 0; i < chosenFieldVal.Len(); i++ {
-				index := int(chosenFieldVal.Index(i).Int())
-				if index < 0 || index >= choicesFieldVal.Len() {
-					return nil, fmt.Errorf("vee: field '%s' index %d out of range for %d choices", chosenField.Name, index, choicesFieldVal.Len())
-				}
-			}
-		} else {
-			index := int(chosenFieldVal.Int())
-			if index < 0 || index >= choicesFieldVal.Len() {
-				return nil, fmt.Errorf("vee: field '%s' index %d out of range for %d choices", chosenField.Name, index, choicesFieldVal.Len())
-			}
+		key, err := choiceKeyFunc(choicesField, configs[choicesField.Name])
+		if err != nil {
+			return nil, err
+		}
+		if key == nil && mode != chosenIndex {
+			return nil, structError(ErrChoicesChosen, choicesField.Name, "vee: choices of field '%s' need a key for '%s': add a Value() string method, implement encoding.TextMarshaler or set valueField", choicesField.Name, chosenField.Name)
 		}
 
-		pairs[baseName] = ChoicesChosenPair{
+		pairs = append(pairs, ChoicesChosenPair{
 			ChoicesField:  choicesField,
 			ChosenField:   chosenField,
-			ChoicesValue:  choicesFieldVal,
-			ChosenValue:   chosenFieldVal,
 			IsMultiSelect: isMultiSelect,
-		}
+			mode:          mode,
+			key:           key,
+			pointer:       isPointer,
+			parent:        -1,
+		})
 	}
 
 	// Check for orphaned Chosen fields
-	for baseName, chosenField := range chosenFields {
-		_, hasChoices := choicesFields[baseName]
-		if hasChoices {
+	for _, chosenField := range chosenFields {
+		baseName := strings.TrimSuffix(chosenField.Name, "Chosen")
+		if _, hasChoices := byName[baseName+"Choices"]; hasChoices {
+			continue
+		}
+		return nil, structError(ErrChoicesChosen, chosenField.Name, "vee: field '%s' requires corresponding '%sChoices' field", chosenField.Name, baseName)
+	}
+
+	return orderPairs(pairs, configs)
+}
+
+// orderPairs resolves the dependsOn attributes of the Choices fields of pairs
+// and orders the pairs so that each comes after the pair it depends on
+func orderPairs(pairs []ChoicesChosenPair, configs map[string]FieldConfig) ([]ChoicesChosenPair, error) {
+	parents := make([]int, len(pairs))
+	for i, pair := range pairs {
+		parents[i] = -1
+		parentName, ok := configs[pair.ChoicesField.Name].Attributes["dependsOn"]
+		if !ok {
+			continue
+		}
+		parents[i] = slices.IndexFunc(pairs, func(parent ChoicesChosenPair) bool {
+			return parent.ChoicesField.Name == parentName+"Choices"
+		})
+		switch {
+		case parents[i] < 0:
+			return nil, structError(ErrChoicesChosen, pair.ChoicesField.Name, "vee: field '%s' depends on '%s', which has no Choices/Chosen pair", pair.ChoicesField.Name, parentName)
+		case pairs[parents[i]].IsMultiSelect:
+			return nil, structError(ErrChoicesChosen, pair.ChoicesField.Name, "vee: field '%s' cannot depend on multi-select field '%s'", pair.ChoicesField.Name, pairs[parents[i]].ChosenField.Name)
+		case !pair.optional():
+			return nil, structError(ErrChoicesChosen, pair.ChosenField.Name, "vee: field '%s' depends on '%s' and must be able to hold no choice: make it a pointer, a key or a slice", pair.ChosenField.Name, parentName)
+		}
+		pairs[i].parentName = configs[pairs[parents[i]].ChosenField.Name].Name
+	}
+
+	// Place pairs after their parents, detecting circular dependencies
+	ordered := make([]ChoicesChosenPair, 0, len(pairs))
+	position := make([]int, len(pairs))
+	placed := make([]bool, len(pairs))
+	for len(ordered) < len(pairs) {
+		progress := false
+		for i := range pairs {
+			if placed[i] || (parents[i] >= 0 && !placed[parents[i]]) {
+				continue
+			}
+			pair := pairs[i]
+			if parents[i] >= 0 {
+				pair.parent = position[parents[i]]
+			}
+			position[i] = len(ordered)
+			placed[i] = true
+			ordered = append(ordered, pair)
+			progress = true
+		}
+		if !progress {
+			i := slices.Index(placed, false)
+			return nil, structError(ErrChoicesChosen, pairs[i].ChoicesField.Name, "vee: field '%s' has a circular dependsOn", pairs[i].ChoicesField.Name)
+		}
+	}
+	return ordered, nil
+}
+
+// validateChoicesValues validates that the choices of a pair are not empty and
+// the chosen indices are in range
+func validateChoicesValues(pair ChoicesChosenPair) error {
+	// Validate choices are not empty unless Chosen can hold no choice
+	if pair.ChoicesValue.Len() == 0 && !pair.optional() {
+		return structError(ErrChoicesChosen, pair.ChoicesField.Name, "vee: field '%s' cannot be empty", pair.ChoicesField.Name)
+	}
+
+	// Validate chosen indices and keys refer to choices
+	options, err := pair.optionValues()
+	if err != nil {
+		return err
+	}
+	chosen, err := pair.chosenValues()
+	if err != nil {
+		return err
+	}
+	for _, value := range chosen {
+		if slices.Contains(options, value) {
 			continue
 		}
-		return nil, fmt.Errorf("vee: field '%s' requires corresponding '%sChoices' field", chosenField.Name, baseName)
+		if pair.mode == chosenIndex {
+			return structError(ErrChoicesChosen, pair.ChosenField.Name, "vee: field '%s' index %s out of range for %d choices", pair.ChosenField.Name, value, len(options))
+		}
+		return structError(ErrChoicesChosen, pair.ChosenField.Name, "vee: field '%s' choice '%s' is not among its choices", pair.ChosenField.Name, value)
 	}
 
-	return pairs, nil
+	return nil
 }
 
 // ChoicesChosenPair represents a validated pair of Choices and Chosen fields
@@ -512,234 +848,181 @@ type ChoicesChosenPair struct {
 	ChoicesValue  reflect.Value
 	ChosenValue   reflect.Value
 	IsMultiSelect bool
+
+	mode       chosenMode                          // How Chosen refers to the choices
+	key        func(reflect.Value) (string, error) // Key of a choice, nil if choices have none
+	pointer    bool                                // Whether Chosen is a pointer, nil for no choice
+	parent     int                                 // Index of the pair the choices depend on, or -1
+	parentName string                              // Form field name of the Chosen field of the parent
 }
 
-// renderMultiValueField renders a Chosen field as select, radio, or checkbox group
-func renderMultiValueField(html *strings.Builder, pair ChoicesChosenPair, config FieldConfig, cssClass, labelCssClass string) error {
+// buildMultiValueField fills in a Field for a Chosen field rendered as select,
+// radio, or checkbox group
+func buildMultiValueField(f *Field, pair ChoicesChosenPair, config FieldConfig, scope fieldScope) (bool, error) {
 	// Determine the input type from attributes (defaults to select)
-	inputType := "select"
+	f.Type = "select"
 	if typeAttr, ok := config.Attributes["type"]; ok {
 		switch typeAttr {
 		case "select", "radio", "checkbox":
-			inputType = typeAttr
+			f.Type = typeAttr
 		}
 	}
 
-	// Get selected indices
-	var selectedIndices []int
-	if pair.IsMultiSelect {
-		for i := 0; i < pair.ChosenValue.Len(); i++ {
-			selectedIndices = append(selectedIndices, int(pair.ChosenValue.Index(i).Int()))
-		}
-	} else {
-		selectedIndices = []int{int(pair.ChosenValue.Int())}
+	// Get the option values and the selected ones
+	options, err := pair.optionValues()
+	if err != nil {
+		return false, err
+	}
+	selected, err := pair.chosenValues()
+	if err != nil {
+		return false, err
 	}
 
-	switch inputType {
+	switch f.Type {
 	case "select":
-		return renderSelectField(html, pair, config, cssClass, labelCssClass, selectedIndices)
+		f.control = controlSelect
+		f.Multiple = pair.IsMultiSelect
 	case "radio":
 		if pair.IsMultiSelect {
-			return fmt.Errorf("vee: radio buttons cannot be used with multi-select field '%s'", pair.ChosenField.Name)
+			return false, fmt.Errorf("vee: radio buttons cannot be used with multi-select field '%s'", pair.ChosenField.Name)
 		}
-		return renderRadioField(html, pair, config, cssClass, labelCssClass, selectedIndices[0])
+		f.control = controlRadioGroup
 	case "checkbox":
-		return renderCheckboxField(html, pair, config, cssClass, labelCssClass, selectedIndices)
+		f.control = controlCheckboxGroup
 	}
 
-	return nil
-}
-
-// renderSelectField renders a select element
-func renderSelectField(html *strings.Builder, pair ChoicesChosenPair, config FieldConfig, cssClass, labelCssClass string, selectedIndices []int) error {
-	// Render label first
-	renderLabel(html, config, pair.ChosenField.Name, labelCssClass)
-
-	html.WriteString("<select")
-	html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-
-	if pair.IsMultiSelect {
-		html.WriteString(" multiple")
+	// Single selects start with the placeholder, chosen if nothing is. An
+	// empty list of choices renders a message instead.
+	f.Options = make([]Option, 0, len(options)+1)
+	if len(options) == 0 {
+		f.Empty = cmp.Or(config.Attributes["empty"], defaultEmptyChoices)
+	} else if f.Placeholder != "" && f.control == controlSelect && !pair.IsMultiSelect {
+		f.Options = append(f.Options, Option{
+			ID:       f.ID + "_placeholder",
+			Label:    f.Placeholder,
+			Selected: len(selected) == 0,
+		})
 	}
+	f.Placeholder = ""
 
-	if cssClass != "" {
-		html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
+	// Dependent fields name their parent for client code refreshing them
+	if pair.parent >= 0 {
+		f.Attrs = append(f.Attrs, Attr{Name: "data-depends-on", Value: scope.fieldName(pair.parentName)})
 	}
 
-	// Add universal attributes
-	addUniversalAttributes(html, config)
-
-	html.WriteString(">\n")
-
-	// Add options
-	for i := 0; i < pair.ChoicesValue.Len(); i++ {
-		choice := pair.ChoicesValue.Index(i).String()
-		html.WriteString(fmt.Sprintf(`<option value="%d"`, i))
-
-		// Check if this option is selected
-		for _, selectedIndex := range selectedIndices {
-			if i == selectedIndex {
-				html.WriteString(" selected")
-				break
-			}
-		}
-
-		html.WriteString(fmt.Sprintf(">%s</option>\n", escapeHTML(choice)))
+	for i, value := range options {
+		choice := pair.choice(i)
+		f.Options = append(f.Options, Option{
+			ID:          f.ID + "_" + strconv.Itoa(i),
+			Value:       value,
+			Label:       choice.Label,
+			Description: choice.Description,
+			Group:       choice.Group,
+			Selected:    slices.Contains(selected, value),
+			Disabled:    choice.Disabled,
+		})
 	}
 
-	html.WriteString("</select>\n")
-	return nil
+	return true, nil
 }
 
-// renderRadioField renders a radio button group
-func renderRadioField(html *strings.Builder, pair ChoicesChosenPair, config FieldConfig, cssClass, labelCssClass string, selectedIndex int) error {
-	// Render group label first (if not disabled)
-	if !config.NoLabel {
-		labelText := generateLabel(config, pair.ChosenField.Name)
-		html.WriteString(`<fieldset><legend`)
-		if labelCssClass != "" {
-			html.WriteString(fmt.Sprintf(` class="%s"`, labelCssClass))
+// buildHiddenField fills in a Field for a hidden input of any supported field
+// type. Slices render a hidden input per element as the child fields.
+func buildHiddenField(f *Field, fs *fieldSchema, fieldVal reflect.Value) (bool, error) {
+	f.control = controlHidden
+	f.Type = "hidden"
+	f.Errors = nil
+
+	if fs.kind == kindSlice {
+		f.Multiple = true
+		f.Presence = hiddenPresence(f)
+		for i := range fieldVal.Len() {
+			value, err := formatHidden(fs.elemKind, fieldVal.Index(i))
+			if err != nil {
+				return false, fmt.Errorf("vee: cannot format hidden field '%s': %w", fs.field.Name, err)
+			}
+			f.Fields = append(f.Fields, hiddenValue(f, i, value))
 		}
-		html.WriteString(fmt.Sprintf(">%s</legend>\n", escapeHTML(labelText)))
+		return true, nil
 	}
 
-	for i := 0; i < pair.ChoicesValue.Len(); i++ {
-		choice := pair.ChoicesValue.Index(i).String()
-		radioID := fmt.Sprintf("%s_%d", config.Name, i)
-
-		html.WriteString(`<input type="radio"`)
-		html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-		html.WriteString(fmt.Sprintf(` value="%d"`, i))
-
-		if i == selectedIndex {
-			html.WriteString(" checked")
-		}
-
-		if cssClass != "" {
-			html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
-		}
-
-		html.WriteString(fmt.Sprintf(` id="%s"`, radioID))
-
-		// Add other universal attributes (except id since we set it specifically)
-		if placeholder, ok := config.Attributes["placeholder"]; ok {
-			html.WriteString(fmt.Sprintf(` placeholder="%s"`, escapeHTML(placeholder)))
-		}
-		if _, ok := config.Attributes["required"]; ok {
-			html.WriteString(` required`)
-		}
-		if _, ok := config.Attributes["readonly"]; ok {
-			html.WriteString(` readonly`)
-		}
-		if _, ok := config.Attributes["disabled"]; ok {
-			html.WriteString(` disabled`)
+	// Nil pointers render without a value
+	if fs.pointer {
+		if fieldVal.IsNil() {
+			return true, nil
 		}
-
-		html.WriteString(fmt.Sprintf(`><label for="%s">%s</label>`, radioID, escapeHTML(choice)))
-		html.WriteString("\n")
+		fieldVal = fieldVal.Elem()
 	}
-
-	// Close fieldset if we opened one
-	if !config.NoLabel {
-		html.WriteString("</fieldset>\n")
+	value, err := formatHidden(fs.kind, fieldVal)
+	if err != nil {
+		return false, fmt.Errorf("vee: cannot format hidden field '%s': %w", fs.field.Name, err)
 	}
-
-	return nil
+	f.Value = value
+	f.HasValue = true
+	return true, nil
 }
 
-// renderCheckboxField renders a checkbox group
-func renderCheckboxField(html *strings.Builder, pair ChoicesChosenPair, config FieldConfig, cssClass, labelCssClass string, selectedIndices []int) error {
-	// Render group label first (if not disabled)
-	if !config.NoLabel {
-		labelText := generateLabel(config, pair.ChosenField.Name)
-		html.WriteString("<fieldset><legend")
-		if labelCssClass != "" {
-			html.WriteString(fmt.Sprintf(` class="%s"`, labelCssClass))
-		}
-		html.WriteString(fmt.Sprintf(">%s</legend>\n", escapeHTML(labelText)))
-	}
-
-	for i := 0; i < pair.ChoicesValue.Len(); i++ {
-		choice := pair.ChoicesValue.Index(i).String()
-		checkboxID := fmt.Sprintf("%s_%d", config.Name, i)
-
-		html.WriteString(`<input type="checkbox"`)
-		html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-		html.WriteString(fmt.Sprintf(` value="%d"`, i))
-
-		// Check if this checkbox is selected
-		for _, selectedIndex := range selectedIndices {
-			if i == selectedIndex {
-				html.WriteString(" checked")
-				break
-			}
-		}
-
-		if cssClass != "" {
-			html.WriteString(fmt.Sprintf(` class="%s"`, escapeHTML(cssClass)))
-		}
-
-		html.WriteString(fmt.Sprintf(` id="%s"`, checkboxID))
+// buildHiddenChosen fills in a Field for a hidden Chosen field holding the
+// chosen indices or keys. Multi-select fields render a hidden input per
+// choice as the child fields.
+func buildHiddenChosen(f *Field, pair ChoicesChosenPair) error {
+	f.control = controlHidden
+	f.Type = "hidden"
+	f.Errors = nil
 
-		// Add other universal attributes (except id since we set it specifically)
-		if placeholder, ok := config.Attributes["placeholder"]; ok {
-			html.WriteString(fmt.Sprintf(` placeholder="%s"`, escapeHTML(placeholder)))
-		}
-		if _, ok := config.Attributes["required"]; ok {
-			html.WriteString(` required`)
-		}
-		if _, ok := config.Attributes["readonly"]; ok {
-			html.WriteString(` readonly`)
-		}
-		if _, ok := config.Attributes["disabled"]; ok {
-			html.WriteString(` disabled`)
+	chosen, err := pair.chosenValues()
+	if err != nil {
+		return err
+	}
+	if !pair.IsMultiSelect {
+		if len(chosen) > 0 {
+			f.Value = chosen[0]
 		}
-
-		html.WriteString(fmt.Sprintf(`><label for="%s">%s</label>`, checkboxID, escapeHTML(choice)))
-		html.WriteString("\n")
+		f.HasValue = true
+		return nil
 	}
-
-	// Close fieldset if we opened one
-	if !config.NoLabel {
-		html.WriteString("</fieldset>\n")
+	f.Multiple = true
+	f.Presence = hiddenPresence(f)
+	for i, value := range chosen {
+		f.Fields = append(f.Fields, hiddenValue(f, i, value))
 	}
-
 	return nil
 }
 
-// addUniversalAttributes adds universal HTML attributes (required, readonly, disabled, placeholder, id)
-func addUniversalAttributes(html *strings.Builder, config FieldConfig) {
-	// Add id attribute (custom or default to field name)
-	if id, ok := config.Attributes["id"]; ok {
-		html.WriteString(fmt.Sprintf(` id="%s"`, escapeHTML(id)))
-	} else {
-		html.WriteString(fmt.Sprintf(` id="%s"`, escapeHTML(config.Name)))
-	}
-
-	// Add placeholder attribute
-	if placeholder, ok := config.Attributes["placeholder"]; ok {
-		html.WriteString(fmt.Sprintf(` placeholder="%s"`, escapeHTML(placeholder)))
+// hiddenPresence returns the presence input of a hidden field with multiple
+// values. It comes first and submits an empty value, so that Bind can tell an
+// empty slice from a form without the field.
+func hiddenPresence(f *Field) *Field {
+	return &Field{
+		StructField: f.StructField,
+		Name:        f.Name,
+		ID:          f.ID,
+		Type:        "hidden",
+		HasValue:    true,
+		control:     controlHidden,
 	}
+}
 
-	// Add boolean attributes (required, readonly, disabled)
-	if _, ok := config.Attributes["required"]; ok {
-		html.WriteString(` required`)
-	}
-	if _, ok := config.Attributes["readonly"]; ok {
-		html.WriteString(` readonly`)
-	}
-	if _, ok := config.Attributes["disabled"]; ok {
-		html.WriteString(` disabled`)
+// hiddenValue returns the i-th hidden input of a hidden field with multiple values
+func hiddenValue(f *Field, i int, value string) Field {
+	return Field{
+		StructField: f.StructField,
+		Name:        f.Name,
+		ID:          f.ID + "_" + strconv.Itoa(i),
+		Type:        "hidden",
+		Value:       value,
+		HasValue:    true,
+		CSS:         f.CSS,
+		control:     controlHidden,
 	}
 }
 
-// escapeHTML escapes HTML characters in attribute values
-func escapeHTML(s string) string {
-	s = strings.ReplaceAll(s, "&", "&amp;")
-	s = strings.ReplaceAll(s, "<", "&lt;")
-	s = strings.ReplaceAll(s, ">", "&gt;")
-	s = strings.ReplaceAll(s, "\"", "&quot;")
-	return s
+// fieldID returns the HTML id of a field (custom or default to field name)
+func fieldID(config FieldConfig) string {
+	if id, ok := config.Attributes["id"]; ok {
+		return id
+	}
+	return config.Name
 }
 
 // generateLabel creates a human-readable label for a field
@@ -766,80 +1049,3 @@ func fieldNameToLabel(fieldName string) string {
 	}
 	return result.String()
 }
-
-// renderLabel generates a <label> element for a field if not disabled
-func renderLabel(html *strings.Builder, config FieldConfig, fieldName string, cssClass string) {
-	if config.NoLabel {
-		return
-	}
-
-	labelText := generateLabel(config, fieldName)
-	fieldID := config.Name
-	if customID, ok := config.Attributes["id"]; ok {
-		fieldID = customID
-	}
-	html.WriteString(fmt.Sprintf(`<label for="%s"`, escapeHTML(fieldID)))
-	if cssClass != "" {
-		html.WriteString(fmt.Sprintf(` class="%s"`, cssClass))
-	}
-	html.WriteString(fmt.Sprintf(">%s</label>\n", escapeHTML(labelText)))
-}
-
-// renderHiddenField renders a hidden input field for any supported field type
-func renderHiddenField(html *strings.Builder, field reflect.StructField, fieldVal reflect.Value, config FieldConfig) error {
-	// Hidden fields never render labels
-	html.WriteString(`<input type="hidden"`)
-	html.WriteString(fmt.Sprintf(` name="%s"`, config.Name))
-
-	// Handle different field types and extract their values
-	actualType := field.Type
-	actualVal := fieldVal
-
-	// Check for specific types first (before generic kind matching)
-	if actualType == reflect.TypeOf(time.Time{}) {
-		timeVal := actualVal.Interface().(time.Time)
-		if !timeVal.IsZero() {
-			// Use ISO format for hidden time fields
-			value := timeVal.Format("2006-01-02T15:04:05Z07:00")
-			html.WriteString(fmt.Sprintf(` value="%s"`, escapeHTML(value)))
-		}
-	} else if actualType == reflect.TypeOf(time.Duration(0)) {
-		durationVal := actualVal.Interface().(time.Duration)
-		if durationVal != 0 {
-			// Store duration as nanoseconds for hidden fields
-			html.WriteString(fmt.Sprintf(` value="%d"`, int64(durationVal)))
-		}
-	} else {
-		// Handle by kind for basic types
-		switch actualType.Kind() {
-		case reflect.String:
-			value := actualVal.String()
-			html.WriteString(fmt.Sprintf(` value="%s"`, escapeHTML(value)))
-
-		case reflect.Int, reflect.Int64:
-			value := actualVal.Int()
-			html.WriteString(fmt.Sprintf(` value="%d"`, value))
-
-		case reflect.Float64:
-			value := actualVal.Float()
-			html.WriteString(fmt.Sprintf(` value="%g"`, value))
-
-		case reflect.Bool:
-			isTrue := actualVal.Bool()
-			if isTrue {
-				html.WriteString(` value="true"`)
-			} else {
-				html.WriteString(` value="false"`)
-			}
-
-		default:
-			return fmt.Errorf("vee: unsupported type for hidden field '%s': %s", field.Name, actualType.Kind())
-		}
-	}
-
-	// Add universal attributes (id is still useful, others may not be but we'll include them)
-	addUniversalAttributes(html, config)
-
-	html.WriteString(">\n")
-	return nil
-}
@@ -1,6 +1,7 @@
 package vee
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -135,7 +136,15 @@ func TestRender(t *testing.T) {
 	}
 }
 
-func TestEscapeHTML(t *testing.T) {
+// escapeText returns s escaped by htmlWriter.text
+func escapeText(s string) string {
+	var b strings.Builder
+	hw := htmlWriter{w: &b}
+	hw.text(s)
+	return b.String()
+}
+
+func TestEscapeText(t *testing.T) {
 	tests := []struct {
 		input string
 		want  string
@@ -149,9 +158,9 @@ func TestEscapeHTML(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			got := escapeHTML(tt.input)
+			got := escapeText(tt.input)
 			if got != tt.want {
-				t.Errorf("escapeHTML(%q) = %q, want %q", tt.input, got, tt.want)
+				t.Errorf("escapeText(%q) = %q, want %q", tt.input, got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,111 @@
+package vee
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func BenchmarkRenderTo(b *testing.B) {
+	form := newBenchForm()
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := RenderTo(io.Discard, form); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkHTMLRenderer measures writing already resolved fields, which
+// does not allocate per field
+func BenchmarkHTMLRenderer(b *testing.B) {
+	options := ConsolidateOptions()
+	fields, err := buildFields(newBenchForm(), options)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var buf bytes.Buffer
+
+	b.ReportAllocs()
+	for b.Loop() {
+		buf.Reset()
+		if err := renderForm(&buf, fields, options, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*benchFields), "ns/field")
+}
+
+func TestHTMLRendererAllocs(t *testing.T) {
+	options := ConsolidateOptions()
+	fields, err := buildFields(newBenchForm(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != benchFields {
+		t.Fatalf("buildFields() returned %d fields, want %d", len(fields), benchFields)
+	}
+
+	var buf bytes.Buffer
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		if err := renderForm(&buf, fields, options, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	// Only the form wrapper is allocated, writing fields does not allocate
+	if allocs > 1 {
+		t.Errorf("HTMLRenderer allocations per form = %v, want at most 1", allocs)
+	}
+}
+
+func TestRenderAllocs(t *testing.T) {
+	form := newBenchForm()
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := Render(form); err != nil {
+			t.Fatal(err)
+		}
+	})
+	// Resolving a field allocates its value and options, writing it does not
+	if perField := allocs / benchFields; perField > 12 {
+		t.Errorf("Render allocations per field = %.1f, want at most 12", perField)
+	}
+}
+
+func TestRenderTo(t *testing.T) {
+	form := newBenchForm()
+	want, err := Render(form, FormIDOption("bench"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out countingWriter
+	if err := RenderTo(&out, form, FormIDOption("bench")); err != nil {
+		t.Fatalf("RenderTo() error = %v", err)
+	}
+	if out.String() != want {
+		t.Errorf("RenderTo() = %q, want %q", out.String(), want)
+	}
+
+	// Output is streamed once for the opening tag, each field and the closing tag
+	if out.writes != benchFields+2 {
+		t.Errorf("RenderTo() wrote %d times, want %d", out.writes, benchFields+2)
+	}
+
+	if err := RenderTo(io.Discard, "not a struct"); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("RenderTo() error = %v, want ErrNotStruct", err)
+	}
+}
+
+// countingWriter records the output and the number of writes
+type countingWriter struct {
+	strings.Builder
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Builder.Write(p)
+}
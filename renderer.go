@@ -0,0 +1,735 @@
+package vee
+
+import (
+	"io"
+	"strings"
+)
+
+// Renderer writes the HTML markup for forms and their fields.
+//
+// vee resolves each struct field into a Field (name, id, value, label, CSS
+// classes, errors, ...) and calls the Renderer to write it. For labeled
+// controls Render calls Label, then the control method (Input, Textarea,
+// Checkbox or Select), then Help and Error. Label, Help and Error are only
+// called when the field has a label, help text or errors respectively.
+// RadioGroup and CheckboxGroup render their own group label. Nested structs
+// are rendered with FieldsetOpen, their fields and FieldsetClose. Slices of
+// structs are rendered the same way with a nested fieldset per row, followed
+// by the row template between TemplateOpen and TemplateClose. Fields of types
+// registered with a FieldCodec that implements ControlWriter write their own
+// control. Duration fields with a units select call Select for it after Input.
+// Hidden fields with multiple values (slices and multi-select Chosen fields)
+// call Hidden with the presence input, then once per value with the child
+// fields.
+//
+// Embed HTMLRenderer to override only some of the methods.
+type Renderer interface {
+	// FormOpen writes the opening form tag
+	FormOpen(w io.Writer, form *Form) error
+
+	// FormClose writes the closing form tag
+	FormClose(w io.Writer, form *Form) error
+
+	// Label writes the label of a field
+	Label(w io.Writer, f *Field) error
+
+	// Input writes a text-like input (text, email, number, date, ...)
+	Input(w io.Writer, f *Field) error
+
+	// Textarea writes a multi-line text control
+	Textarea(w io.Writer, f *Field) error
+
+	// Checkbox writes a single boolean checkbox
+	Checkbox(w io.Writer, f *Field) error
+
+	// Select writes a select element with its options
+	Select(w io.Writer, f *Field) error
+
+	// RadioGroup writes a group of radio buttons including the group label
+	RadioGroup(w io.Writer, f *Field) error
+
+	// CheckboxGroup writes a group of checkboxes including the group label
+	CheckboxGroup(w io.Writer, f *Field) error
+
+	// Hidden writes a hidden input
+	Hidden(w io.Writer, f *Field) error
+
+	// FieldsetOpen writes the opening fieldset tag and legend of a nested struct
+	FieldsetOpen(w io.Writer, f *Field) error
+
+	// FieldsetClose writes the closing fieldset tag of a nested struct
+	FieldsetClose(w io.Writer, f *Field) error
+
+	// TemplateOpen writes the opening tag of the row template of a slice
+	TemplateOpen(w io.Writer, f *Field) error
+
+	// TemplateClose writes the closing tag of the row template of a slice
+	TemplateClose(w io.Writer, f *Field) error
+
+	// Help writes the help text of a field
+	Help(w io.Writer, f *Field) error
+
+	// Error writes the error messages of a field
+	Error(w io.Writer, f *Field) error
+}
+
+// Form holds the attributes of the form wrapper.
+type Form struct {
+	ID      string // HTML id attribute
+	CSS     string // CSS classes
+	Method  string // HTTP method, empty for forms submitted via JavaScript
+	Action  string // Action URL
+	Enctype string // Encoding of the submitted data, multipart/form-data for forms with file inputs
+}
+
+// Field holds everything needed to render a single form control.
+type Field struct {
+	StructField string // Go struct field path (Address.Street for nested fields)
+	Name        string // HTML form field name
+	ID          string // HTML id attribute
+	Type        string // Input type (text, number, textarea, checkbox, select, radio, ...)
+	Value       string // Formatted value
+	HasValue    bool   // Whether the value attribute should be rendered
+	Checked     bool   // Whether a checkbox is checked
+	Multiple    bool   // Whether multiple options can be selected, or a hidden field has one child field per value
+	Output      bool   // Whether a range input is followed by an <output> showing its value
+
+	Label    string // Label text
+	NoLabel  bool   // Whether label rendering is disabled
+	CSS      string // CSS classes of the control, including the error CSS class
+	LabelCSS string // CSS classes of the label
+	ErrorCSS string // CSS classes of the error message element
+
+	Placeholder string
+	Help        string
+	Required    bool
+	Readonly    bool
+	Disabled    bool
+
+	Attrs    []Attr   // Type-specific attributes (min, max, step, ...)
+	Options  []Option // Options of select, radio and checkbox groups
+	Empty    string   // Message rendered instead of the options when there are none
+	Fields   []Field  // Fields of a nested struct, rows of a slice, or values of a hidden field
+	Template *Field   // Empty row of a slice for cloning on the client
+	Presence *Field   // Hidden input submitted with the rows or hidden values of a slice, even if there are none
+	Units    *Field   // Units select rendered after the input of a duration field
+	Errors   []string // Error messages
+
+	control control
+	writer  ControlWriter // Control markup of registered types (controlCustom only)
+}
+
+// Attr is an HTML attribute. An empty Value renders a boolean attribute.
+type Attr struct {
+	Name  string
+	Value string
+}
+
+// Option is a single choice of a select, radio or checkbox group.
+type Option struct {
+	ID          string // HTML id of radio and checkbox inputs
+	Value       string
+	Label       string
+	Description string // Help text of the option
+	Group       string // Group of the option, consecutive options of a group are rendered together
+	Selected    bool
+	Disabled    bool
+}
+
+// HelpID returns the id of the help text element
+func (f *Field) HelpID() string {
+	return f.ID + "_help"
+}
+
+// ErrorID returns the id of the error message element
+func (f *Field) ErrorID() string {
+	return f.ID + "_error"
+}
+
+// OutputID returns the id of the output element showing the value of a range input
+func (f *Field) OutputID() string {
+	return f.ID + "_output"
+}
+
+// TemplateID returns the id of the row template element of a slice
+func (f *Field) TemplateID() string {
+	return f.ID + "_template"
+}
+
+// DescribedBy returns the ids of the elements describing the field,
+// suitable for the aria-describedby attribute
+func (f *Field) DescribedBy() string {
+	var ids []string
+	if f.Help != "" {
+		ids = append(ids, f.HelpID())
+	}
+	if len(f.Errors) > 0 {
+		ids = append(ids, f.ErrorID())
+	}
+	return strings.Join(ids, " ")
+}
+
+// control identifies the Renderer method used for a field
+type control int
+
+const (
+	controlInput control = iota
+	controlTextarea
+	controlCheckbox
+	controlSelect
+	controlRadioGroup
+	controlCheckboxGroup
+	controlHidden
+	controlFieldset
+	controlCustom
+)
+
+// writeField renders a field with its label, help text and errors
+func writeField(r Renderer, w io.Writer, f *Field) error {
+	if f.control == controlHidden {
+		return writeHidden(r, w, f)
+	}
+
+	// Radio and checkbox groups and fieldsets render their own label
+	if f.control != controlRadioGroup && f.control != controlCheckboxGroup && f.control != controlFieldset {
+		if err := writeLabel(r, w, f); err != nil {
+			return err
+		}
+	}
+	if err := writeControl(r, w, f); err != nil {
+		return err
+	}
+	if f.Help != "" {
+		if err := r.Help(w, f); err != nil {
+			return err
+		}
+	}
+	return writeErrors(r, w, f)
+}
+
+// writeLabel renders the label of a field unless it is disabled or hidden
+func writeLabel(r Renderer, w io.Writer, f *Field) error {
+	if f.NoLabel || f.control == controlHidden || f.control == controlFieldset {
+		return nil
+	}
+	return r.Label(w, f)
+}
+
+// writeControl renders the control of a field using the matching Renderer method
+func writeControl(r Renderer, w io.Writer, f *Field) error {
+	switch f.control {
+	case controlTextarea:
+		return r.Textarea(w, f)
+	case controlCheckbox:
+		return r.Checkbox(w, f)
+	case controlSelect:
+		return r.Select(w, f)
+	case controlRadioGroup:
+		return r.RadioGroup(w, f)
+	case controlCheckboxGroup:
+		return r.CheckboxGroup(w, f)
+	case controlHidden:
+		return writeHidden(r, w, f)
+	case controlFieldset:
+		return writeFieldset(r, w, f)
+	case controlCustom:
+		return f.writer.WriteControl(w, f)
+	default:
+		if err := r.Input(w, f); err != nil {
+			return err
+		}
+		if f.Units != nil {
+			return r.Select(w, f.Units)
+		}
+		return nil
+	}
+}
+
+// writeFieldset renders a nested struct wrapped in a fieldset
+func writeFieldset(r Renderer, w io.Writer, f *Field) error {
+	if err := r.FieldsetOpen(w, f); err != nil {
+		return err
+	}
+	if f.Presence != nil {
+		if err := r.Hidden(w, f.Presence); err != nil {
+			return err
+		}
+	}
+	for i := range f.Fields {
+		if err := writeField(r, w, &f.Fields[i]); err != nil {
+			return err
+		}
+	}
+	if f.Template != nil {
+		if err := r.TemplateOpen(w, f); err != nil {
+			return err
+		}
+		if err := writeFieldset(r, w, f.Template); err != nil {
+			return err
+		}
+		if err := r.TemplateClose(w, f); err != nil {
+			return err
+		}
+	}
+	return r.FieldsetClose(w, f)
+}
+
+// writeHidden renders a hidden field, or a hidden input per value of a hidden
+// field with multiple values
+func writeHidden(r Renderer, w io.Writer, f *Field) error {
+	if !f.Multiple {
+		return r.Hidden(w, f)
+	}
+	if err := r.Hidden(w, f.Presence); err != nil {
+		return err
+	}
+	for i := range f.Fields {
+		if err := r.Hidden(w, &f.Fields[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeErrors renders the error messages of a field if it has any
+func writeErrors(r Renderer, w io.Writer, f *Field) error {
+	if len(f.Errors) == 0 {
+		return nil
+	}
+	return r.Error(w, f)
+}
+
+// HTMLRenderer is the default Renderer producing plain HTML5 markup.
+type HTMLRenderer struct{}
+
+var _ Renderer = HTMLRenderer{}
+
+func (HTMLRenderer) FormOpen(w io.Writer, form *Form) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<form")
+	if form.ID != "" {
+		hw.attr("id", form.ID)
+	}
+	hw.class(form.CSS)
+	if form.Method != "" {
+		hw.attr("method", form.Method)
+	}
+	if form.Action != "" {
+		hw.attr("action", form.Action)
+	}
+	if form.Enctype != "" {
+		hw.attr("enctype", form.Enctype)
+	}
+	hw.raw(">\n")
+	return hw.err
+}
+
+func (HTMLRenderer) FormClose(w io.Writer, form *Form) error {
+	hw := htmlWriter{w: w}
+	hw.raw("</form>\n")
+	return hw.err
+}
+
+func (HTMLRenderer) Label(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<label")
+	hw.attr("for", f.ID)
+	hw.class(f.LabelCSS)
+	hw.raw(">")
+	hw.text(f.Label)
+	hw.raw("</label>\n")
+	return hw.err
+}
+
+func (HTMLRenderer) Input(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<input")
+	hw.attr("type", f.Type)
+	hw.attr("name", f.Name)
+	if f.HasValue {
+		hw.attr("value", f.Value)
+	}
+	hw.attrs(f.Attrs)
+	hw.class(f.CSS)
+	hw.commonAttrs(f, f.ID)
+	if f.Output {
+		// The output element directly follows the input
+		hw.attr("oninput", "this.nextElementSibling.value=this.value")
+	}
+	hw.raw(">\n")
+	if f.Output {
+		hw.raw("<output")
+		hw.attr("for", f.ID)
+		hw.attr("id", f.OutputID())
+		hw.raw(">")
+		hw.text(f.Value)
+		hw.raw("</output>\n")
+	}
+	return hw.err
+}
+
+func (HTMLRenderer) Textarea(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<textarea")
+	hw.attr("name", f.Name)
+	hw.attrs(f.Attrs)
+	hw.class(f.CSS)
+	hw.commonAttrs(f, f.ID)
+	hw.raw(">")
+
+	// The HTML parser drops a newline directly after the start tag, so a
+	// leading newline of the value needs an extra one to survive
+	if strings.HasPrefix(f.Value, "\n") || strings.HasPrefix(f.Value, "\r") {
+		hw.raw("\n")
+	}
+	hw.text(f.Value)
+	hw.raw("</textarea>\n")
+	return hw.err
+}
+
+func (HTMLRenderer) Checkbox(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw(`<input type="checkbox"`)
+	hw.attr("name", f.Name)
+	hw.attr("value", f.Value)
+	if f.Checked {
+		hw.raw(" checked")
+	}
+	hw.class(f.CSS)
+	hw.commonAttrs(f, f.ID)
+	hw.raw(">\n")
+	return hw.err
+}
+
+func (HTMLRenderer) Select(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<select")
+	hw.attr("name", f.Name)
+	if f.Multiple {
+		hw.raw(" multiple")
+	}
+	hw.attrs(f.Attrs)
+	hw.class(f.CSS)
+	hw.commonAttrs(f, f.ID)
+	hw.raw(">\n")
+
+	if len(f.Options) == 0 && f.Empty != "" {
+		hw.raw(`<option value="" disabled selected>`)
+		hw.text(f.Empty)
+		hw.raw("</option>\n")
+	}
+
+	group := ""
+	for _, option := range f.Options {
+		if option.Group != group {
+			if group != "" {
+				hw.raw("</optgroup>\n")
+			}
+			if group = option.Group; group != "" {
+				hw.raw("<optgroup")
+				hw.attr("label", group)
+				hw.raw(">\n")
+			}
+		}
+
+		hw.raw("<option")
+		hw.attr("value", option.Value)
+		if option.Description != "" {
+			hw.attr("title", option.Description)
+		}
+		if option.Selected {
+			hw.raw(" selected")
+		}
+		if option.Disabled {
+			hw.raw(" disabled")
+		}
+		hw.raw(">")
+		hw.text(option.Label)
+		hw.raw("</option>\n")
+	}
+	if group != "" {
+		hw.raw("</optgroup>\n")
+	}
+
+	hw.raw("</select>\n")
+	return hw.err
+}
+
+func (HTMLRenderer) RadioGroup(w io.Writer, f *Field) error {
+	return writeGroup(w, f, "radio")
+}
+
+func (HTMLRenderer) CheckboxGroup(w io.Writer, f *Field) error {
+	return writeGroup(w, f, "checkbox")
+}
+
+func (HTMLRenderer) Hidden(w io.Writer, f *Field) error {
+	// Hidden fields never render labels
+	hw := htmlWriter{w: w}
+	hw.raw(`<input type="hidden"`)
+	hw.attr("name", f.Name)
+	if f.HasValue {
+		hw.attr("value", f.Value)
+	}
+	hw.commonAttrs(f, f.ID)
+	hw.raw(">\n")
+	return hw.err
+}
+
+func (HTMLRenderer) FieldsetOpen(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<fieldset")
+	hw.attr("id", f.ID)
+	hw.attrs(f.Attrs)
+	hw.class(f.CSS)
+	if f.Disabled {
+		hw.raw(" disabled")
+	}
+	if describedBy := f.DescribedBy(); describedBy != "" {
+		hw.attr("aria-describedby", describedBy)
+	}
+	hw.raw(">")
+	if !f.NoLabel {
+		hw.raw("<legend")
+		hw.class(f.LabelCSS)
+		hw.raw(">")
+		hw.text(f.Label)
+		hw.raw("</legend>")
+	}
+	hw.raw("\n")
+	return hw.err
+}
+
+func (HTMLRenderer) FieldsetClose(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("</fieldset>\n")
+	return hw.err
+}
+
+func (HTMLRenderer) TemplateOpen(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<template")
+	hw.attr("id", f.TemplateID())
+	hw.raw(">\n")
+	return hw.err
+}
+
+func (HTMLRenderer) TemplateClose(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("</template>\n")
+	return hw.err
+}
+
+func (HTMLRenderer) Help(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<small")
+	hw.attr("id", f.HelpID())
+	hw.raw(">")
+	hw.text(f.Help)
+	hw.raw("</small>\n")
+	return hw.err
+}
+
+func (HTMLRenderer) Error(w io.Writer, f *Field) error {
+	hw := htmlWriter{w: w}
+	hw.raw("<span")
+	hw.attr("id", f.ErrorID())
+	hw.class(f.ErrorCSS)
+	hw.raw(">")
+	for i, message := range f.Errors {
+		if i > 0 {
+			hw.raw("<br>")
+		}
+		hw.text(message)
+	}
+	hw.raw("</span>\n")
+	return hw.err
+}
+
+// writeGroup writes a radio or checkbox group wrapped in a fieldset with legend.
+// Groups without a label keep the fieldset if it carries attributes.
+func writeGroup(w io.Writer, f *Field, inputType string) error {
+	hw := htmlWriter{w: w}
+
+	// Render group label first (if not disabled)
+	wrapped := !f.NoLabel || len(f.Attrs) > 0
+	if wrapped {
+		hw.raw("<fieldset")
+		hw.attrs(f.Attrs)
+		hw.raw(">")
+	}
+	if !f.NoLabel {
+		hw.raw("<legend")
+		hw.class(f.LabelCSS)
+		hw.raw(">")
+		hw.text(f.Label)
+		hw.raw("</legend>")
+	}
+	if wrapped {
+		hw.raw("\n")
+	}
+
+	if len(f.Options) == 0 && f.Empty != "" {
+		hw.raw("<p>")
+		hw.text(f.Empty)
+		hw.raw("</p>\n")
+	}
+
+	// The required attribute would make every checkbox of a group required,
+	// so a required choice is only checked by Bind
+	control := f
+	if inputType == "checkbox" && f.Required {
+		optional := *f
+		optional.Required = false
+		control = &optional
+	}
+
+	group := ""
+	for _, option := range f.Options {
+		// Options of a group are wrapped in a nested fieldset
+		if option.Group != group {
+			if group != "" {
+				hw.raw("</fieldset>\n")
+			}
+			if group = option.Group; group != "" {
+				hw.raw("<fieldset><legend>")
+				hw.text(group)
+				hw.raw("</legend>\n")
+			}
+		}
+
+		hw.raw("<input")
+		hw.attr("type", inputType)
+		hw.attr("name", f.Name)
+		hw.attr("value", option.Value)
+		if option.Selected {
+			hw.raw(" checked")
+		}
+		hw.class(f.CSS)
+		hw.commonAttrs(control, option.ID)
+		if option.Disabled && !f.Disabled {
+			hw.raw(" disabled")
+		}
+		hw.raw("><label")
+		hw.attr("for", option.ID)
+		hw.raw(">")
+		hw.text(option.Label)
+		if option.Description != "" {
+			hw.raw(" <small>")
+			hw.text(option.Description)
+			hw.raw("</small>")
+		}
+		hw.raw("</label>\n")
+	}
+	if group != "" {
+		hw.raw("</fieldset>\n")
+	}
+
+	// Close fieldset if we opened one
+	if wrapped {
+		hw.raw("</fieldset>\n")
+	}
+
+	return hw.err
+}
+
+// htmlWriter writes markup directly to an io.Writer without intermediate
+// allocations and records the first write error
+type htmlWriter struct {
+	w   io.Writer
+	err error
+}
+
+// raw writes s unescaped
+func (hw *htmlWriter) raw(s string) {
+	if hw.err == nil && s != "" {
+		_, hw.err = io.WriteString(hw.w, s)
+	}
+}
+
+// text writes s with HTML characters escaped
+func (hw *htmlWriter) text(s string) {
+	last := 0
+	for i := 0; i < len(s); i++ {
+		var escaped string
+		switch s[i] {
+		case '&':
+			escaped = "&amp;"
+		case '<':
+			escaped = "&lt;"
+		case '>':
+			escaped = "&gt;"
+		case '"':
+			escaped = "&quot;"
+		default:
+			continue
+		}
+		hw.raw(s[last:i])
+		hw.raw(escaped)
+		last = i + 1
+	}
+	hw.raw(s[last:])
+}
+
+// attr writes an attribute with an escaped value
+func (hw *htmlWriter) attr(name, value string) {
+	hw.raw(" ")
+	hw.raw(name)
+	hw.raw(`="`)
+	hw.text(value)
+	hw.raw(`"`)
+}
+
+// attrs writes type-specific attributes
+func (hw *htmlWriter) attrs(attrs []Attr) {
+	for _, attr := range attrs {
+		if attr.Value == "" {
+			hw.raw(" ")
+			hw.raw(attr.Name)
+			continue
+		}
+		hw.attr(attr.Name, attr.Value)
+	}
+}
+
+// class writes a class attribute if css is not empty
+func (hw *htmlWriter) class(css string) {
+	if css != "" {
+		hw.attr("class", css)
+	}
+}
+
+// commonAttrs writes the id, universal and accessibility attributes
+func (hw *htmlWriter) commonAttrs(f *Field, id string) {
+	hw.attr("id", id)
+
+	if f.Placeholder != "" {
+		hw.attr("placeholder", f.Placeholder)
+	}
+
+	// Add boolean attributes (required, readonly, disabled)
+	if f.Required {
+		hw.raw(" required")
+	}
+	if f.Readonly {
+		hw.raw(" readonly")
+	}
+	if f.Disabled {
+		hw.raw(" disabled")
+	}
+
+	if len(f.Errors) > 0 {
+		hw.raw(` aria-invalid="true"`)
+		hw.raw(" aria-describedby=\"")
+		if f.Help != "" {
+			hw.text(f.ID)
+			hw.raw("_help ")
+		}
+		hw.text(f.ID)
+		hw.raw(`_error"`)
+	} else if f.Help != "" {
+		hw.raw(" aria-describedby=\"")
+		hw.text(f.ID)
+		hw.raw(`_help"`)
+	}
+}
@@ -0,0 +1,173 @@
+package vee
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// gridRenderer wraps inputs in a div and renders the label after the input
+type gridRenderer struct {
+	HTMLRenderer
+}
+
+func (gridRenderer) Label(w io.Writer, f *Field) error {
+	return nil
+}
+
+func (r gridRenderer) Input(w io.Writer, f *Field) error {
+	if _, err := io.WriteString(w, `<div class="cell">`+"\n"); err != nil {
+		return err
+	}
+	if err := r.HTMLRenderer.Input(w, f); err != nil {
+		return err
+	}
+	if err := r.HTMLRenderer.Label(w, f); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "</div>\n")
+	return err
+}
+
+func (gridRenderer) Error(w io.Writer, f *Field) error {
+	_, err := fmt.Fprintf(w, "<p role=\"alert\">%s</p>\n", escapeText(strings.Join(f.Errors, ", ")))
+	return err
+}
+
+func TestCustomRenderer(t *testing.T) {
+	type User struct {
+		Name   string `css:"input"`
+		Active bool
+	}
+
+	errs := make(FieldErrors)
+	errs.Add("Name", "Too short")
+
+	got, err := Render(User{Name: "J", Active: true}, RendererOption(gridRenderer{}), RenderOption{Errors: errs})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<form method="POST">
+<div class="cell">
+<input type="text" name="name" value="J" class="input error" id="name" aria-invalid="true" aria-describedby="name_error">
+<label for="name">Name</label>
+</div>
+<p role="alert">Too short</p>
+<input type="checkbox" name="active" value="true" checked id="active">
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+// recordingRenderer records which Renderer methods are called
+type recordingRenderer struct {
+	HTMLRenderer
+	calls *[]string
+}
+
+func (r recordingRenderer) record(method string, f *Field) {
+	*r.calls = append(*r.calls, method+":"+f.StructField)
+}
+
+func (r recordingRenderer) Label(w io.Writer, f *Field) error {
+	r.record("Label", f)
+	return nil
+}
+
+func (r recordingRenderer) Input(w io.Writer, f *Field) error {
+	r.record("Input", f)
+	return nil
+}
+
+func (r recordingRenderer) Checkbox(w io.Writer, f *Field) error {
+	r.record("Checkbox", f)
+	return nil
+}
+
+func (r recordingRenderer) Select(w io.Writer, f *Field) error {
+	r.record("Select", f)
+	return nil
+}
+
+func (r recordingRenderer) RadioGroup(w io.Writer, f *Field) error {
+	r.record("RadioGroup", f)
+	return nil
+}
+
+func (r recordingRenderer) CheckboxGroup(w io.Writer, f *Field) error {
+	r.record("CheckboxGroup", f)
+	return nil
+}
+
+func (r recordingRenderer) Hidden(w io.Writer, f *Field) error {
+	r.record("Hidden", f)
+	return nil
+}
+
+func (r recordingRenderer) Help(w io.Writer, f *Field) error {
+	r.record("Help", f)
+	return nil
+}
+
+func TestRendererDispatch(t *testing.T) {
+	type Form struct {
+		ID              int `vee:"hidden"`
+		Name            string
+		Email           string `vee:"nolabel,help:'We never share it'"`
+		Active          bool
+		ColorChoices    []string
+		ColorChosen     int
+		SizeChoices     []string
+		SizeChosen      int `vee:"type:'radio'"`
+		FeatureChoices  []string
+		FeatureChosen   []int `vee:"type:'checkbox'"`
+		Unsupported     map[string]string
+		unexportedField string
+	}
+
+	var calls []string
+	_, err := Render(Form{
+		ColorChoices:   []string{"Red"},
+		SizeChoices:    []string{"S"},
+		FeatureChoices: []string{"Fast"},
+	}, RendererOption(recordingRenderer{calls: &calls}))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := []string{
+		"Hidden:ID",
+		"Label:Name", "Input:Name",
+		"Input:Email", "Help:Email",
+		"Label:Active", "Checkbox:Active",
+		"Label:ColorChosen", "Select:ColorChosen",
+		"RadioGroup:SizeChosen",
+		"CheckboxGroup:FeatureChosen",
+	}
+	if strings.Join(calls, " ") != strings.Join(want, " ") {
+		t.Errorf("Renderer calls = %v, want %v", calls, want)
+	}
+}
+
+func TestHelpText(t *testing.T) {
+	got, err := Render(struct {
+		Email string `vee:"help:'We never share it'"`
+	}{})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<form method="POST">
+<label for="email">Email</label>
+<input type="text" name="email" value="" id="email" aria-describedby="email_help">
+<small id="email_help">We never share it</small>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
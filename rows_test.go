@@ -0,0 +1,259 @@
+package vee
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type rowsLineItem struct {
+	ID  int    `vee:"-"`
+	SKU string `vee:"label:'SKU'"`
+	Qty int
+}
+
+type rowsOrder struct {
+	Items []rowsLineItem `vee:"min:1,max:3" css:"rows"`
+}
+
+func TestRowsRendering(t *testing.T) {
+	order := rowsOrder{Items: []rowsLineItem{{SKU: "A-1", Qty: 2}}}
+
+	got, err := Render(order)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<form method="POST">
+<fieldset id="items" data-min-rows="1" data-max-rows="3" class="rows"><legend>Items</legend>
+<input type="hidden" name="items" value="" id="items_rows">
+<fieldset id="items_0">
+<label for="items_0.sku">SKU</label>
+<input type="text" name="items[0].sku" value="A-1" id="items_0.sku">
+<label for="items_0.qty">Qty</label>
+<input type="number" name="items[0].qty" value="2" id="items_0.qty">
+</fieldset>
+<template id="items_template">
+<fieldset id="items___index__">
+<label for="items___index__.sku">SKU</label>
+<input type="text" name="items[__index__].sku" value="" id="items___index__.sku">
+<label for="items___index__.qty">Qty</label>
+<input type="number" name="items[__index__].qty" value="0" id="items___index__.qty">
+</fieldset>
+</template>
+</fieldset>
+</form>
+`
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestRowsRenderingMinRows(t *testing.T) {
+	type Guest struct {
+		Name string
+	}
+	type Party struct {
+		Guests []*Guest `vee:"min:3"`
+	}
+
+	got, err := Render(Party{Guests: []*Guest{{Name: "Ann"}, nil}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := []string{
+		`<input type="text" name="guests[0].name" value="Ann" id="guests_0.name">`,
+		`<input type="text" name="guests[1].name" value="" id="guests_1.name">`,
+		`<input type="text" name="guests[2].name" value="" id="guests_2.name">`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, got)
+		}
+	}
+	if strings.Contains(got, "guests[3]") {
+		t.Errorf("Render() rendered more than the minimum rows:\n%s", got)
+	}
+}
+
+func TestRowsRenderingChoices(t *testing.T) {
+	type Line struct {
+		SizeChoices  []string
+		SizeChosen   int `vee:"type:'radio'"`
+		ExtraChoices []string
+		ExtraChosen  []int `vee:"type:'checkbox',id:'extras'"`
+	}
+	type Order struct {
+		Lines []Line
+	}
+
+	got, err := Render(Order{Lines: []Line{{SizeChoices: []string{"S", "M"}, ExtraChoices: []string{"Gift wrap"}}}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := []string{
+		`<input type="radio" name="lines[0].size_chosen" value="0" checked id="lines_0.size_chosen_0"><label for="lines_0.size_chosen_0">S</label>`,
+		`<input type="radio" name="lines[0].size_chosen" value="1" id="lines_0.size_chosen_1"><label for="lines_0.size_chosen_1">M</label>`,
+		`<input type="checkbox" name="lines[0].extra_chosen" value="0" id="lines_0.extras_0"><label for="lines_0.extras_0">Gift wrap</label>`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, got)
+		}
+	}
+}
+
+func TestRowsBinding(t *testing.T) {
+	t.Run("sparse and reordered indices", func(t *testing.T) {
+		order := rowsOrder{Items: []rowsLineItem{
+			{ID: 10, SKU: "A"},
+			{ID: 11, SKU: "B"},
+			{ID: 12, SKU: "C"},
+		}}
+
+		// Row 1 was deleted, row 7 was added on the client
+		err := Bind(map[string][]string{
+			"items[7].sku": {"New"},
+			"items[2].sku": {"C2"},
+			"items[0].sku": {"A2"},
+			"items[0].qty": {"5"},
+		}, &order)
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+
+		want := []rowsLineItem{
+			{ID: 10, SKU: "A2", Qty: 5},
+			{ID: 12, SKU: "C2"},
+			{SKU: "New"},
+		}
+		if len(order.Items) != len(want) {
+			t.Fatalf("Bind() Items = %+v, want %+v", order.Items, want)
+		}
+		for i := range want {
+			if order.Items[i] != want[i] {
+				t.Errorf("Items[%d] = %+v, want %+v", i, order.Items[i], want[i])
+			}
+		}
+	})
+
+	t.Run("pointer rows", func(t *testing.T) {
+		type Phone struct {
+			Number string
+		}
+		var form struct {
+			Phones []*Phone
+		}
+		if err := Bind(map[string][]string{"phones[3].number": {"555"}, "phones[1].number": {"123"}}, &form); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if len(form.Phones) != 2 || form.Phones[0].Number != "123" || form.Phones[1].Number != "555" {
+			t.Errorf("Bind() Phones = %+v, want [123 555]", form.Phones)
+		}
+	})
+
+	t.Run("all rows deleted", func(t *testing.T) {
+		var form struct {
+			Items []rowsLineItem
+		}
+		form.Items = []rowsLineItem{{SKU: "A"}}
+		if err := Bind(map[string][]string{"items": {""}}, &form); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if form.Items != nil {
+			t.Errorf("Bind() Items = %+v, want nil", form.Items)
+		}
+	})
+
+	t.Run("rows not submitted", func(t *testing.T) {
+		var form struct {
+			Name  string
+			Items []rowsLineItem `vee:"min:3"`
+		}
+		form.Items = []rowsLineItem{{SKU: "A"}, {SKU: "B"}}
+		if err := Bind(map[string][]string{"name": {"y"}}, &form); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if len(form.Items) != 2 {
+			t.Errorf("Bind() Items = %+v, want unchanged", form.Items)
+		}
+	})
+
+	t.Run("malformed names are ignored", func(t *testing.T) {
+		var form struct {
+			Items []rowsLineItem
+		}
+		err := Bind(map[string][]string{
+			"items[-1].sku": {"x"},
+			"items[+1].sku": {"x"},
+			"items[].sku":   {"x"},
+			"items[1]sku":   {"x"},
+			"items[a].sku":  {"x"},
+			"items[1].sku":  {"ok"},
+		}, &form)
+		if err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if len(form.Items) != 1 || form.Items[0].SKU != "ok" {
+			t.Errorf("Bind() Items = %+v, want one row 'ok'", form.Items)
+		}
+	})
+}
+
+func TestRowsBindingErrors(t *testing.T) {
+	var order rowsOrder
+	err := Bind(map[string][]string{
+		"items[0].qty": {"1"},
+		"items[1].qty": {"2"},
+		"items[2].qty": {"3"},
+		"items[3].qty": {"4"},
+	}, &order)
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Kind != KindRows || fieldErr.Field != "Items" {
+		t.Fatalf("Bind() error = %v, want row count FieldError", err)
+	}
+	if msg := fieldErr.Message(); msg != "Must have at most 3 rows" {
+		t.Errorf("Message() = %q, want 'Must have at most 3 rows'", msg)
+	}
+	if order.Items != nil {
+		t.Errorf("Bind() Items = %+v, want unchanged", order.Items)
+	}
+
+	err = Bind(map[string][]string{"items": {""}}, &order)
+	if got := CollectErrors(err).Get("Items"); len(got) != 1 || got[0] != "Must have at least 1 row" {
+		t.Errorf("CollectErrors() = %v, want minimum row count message", got)
+	}
+
+	// Errors inside rows refer to the position in the bound slice
+	err = Bind(map[string][]string{
+		"items[4].qty": {"1"},
+		"items[9].qty": {"many"},
+	}, &order)
+	var bindErrs BindErrors
+	if !errors.As(err, &bindErrs) || len(bindErrs) != 1 {
+		t.Fatalf("Bind() error = %v, want one BindErrors entry", err)
+	}
+	if bindErrs[0].Field != "Items[1].Qty" || bindErrs[0].Name != "items[9].qty" {
+		t.Errorf("FieldError = %+v, want Field 'Items[1].Qty' and Name 'items[9].qty'", bindErrs[0])
+	}
+}
+
+func TestRowsRenderField(t *testing.T) {
+	order := rowsOrder{Items: []rowsLineItem{{SKU: "A"}, {SKU: "B"}}}
+
+	errs := make(FieldErrors)
+	errs.Add("Items[1].SKU", "Unknown product")
+
+	got, err := RenderInput(order, "Items[1].SKU", RenderOption{Errors: errs})
+	if err != nil {
+		t.Fatalf("RenderInput() error = %v", err)
+	}
+	want := `<input type="text" name="items[1].sku" value="B" class="error" id="items_1.sku" aria-invalid="true" aria-describedby="items_1.sku_error">
+`
+	if got != want {
+		t.Errorf("RenderInput() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,689 @@
+package vee
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"math"
+	"mime/multipart"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fieldKind is the resolved kind of a struct field
+type fieldKind int
+
+const (
+	kindUnsupported fieldKind = iota
+	kindString
+	kindInt
+	kindUint
+	kindFloat
+	kindBool
+	kindTime
+	kindDuration
+	kindChoices
+	kindChosen
+	kindStruct
+	kindStructSlice
+	kindText
+	kindCodec
+	kindFile
+	kindFiles
+	kindSlice
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	fileType     = reflect.TypeOf(multipart.FileHeader{})
+
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// typeSchema is the compiled, immutable description of a struct type shared by
+// Render, Bind and Choices/Chosen validation. It is computed once per type.
+type typeSchema struct {
+	typ    reflect.Type
+	fields []fieldSchema
+
+	// pairs holds the Choices/Chosen pairs in field order, except that pairs
+	// come after the pair they depend on
+	pairs []ChoicesChosenPair
+
+	// err holds structural problems (Choices/Chosen pairing and types)
+	err error
+
+	// renderErr holds problems that only prevent rendering (hidden restrictions)
+	renderErr error
+}
+
+// fieldSchema describes a single exported, non-skipped struct field. Fields
+// promoted from embedded structs have the full index path in field.Index.
+type fieldSchema struct {
+	field   reflect.StructField
+	config  FieldConfig
+	label   string
+	kind    fieldKind
+	pointer bool // Whether the field is a pointer to its kind
+
+	css      string // css tag
+	labelCSS string // labelCss tag
+	errorCSS string // errorCss tag
+
+	// Index into typeSchema.pairs (kindChosen only)
+	pair int
+
+	// Registered codec of the field type (kindCodec only)
+	codec FieldCodec
+
+	// Maximum size of each uploaded file from the maxsize attribute, 0 for
+	// no limit (kindFile and kindFiles only)
+	maxSize int64
+
+	// Time zone from the tz attribute, overriding the render and bind
+	// options, and the input type and layout of the values (kindTime only)
+	location *time.Location
+	time     timeInput
+
+	// Units and format of the values (kindDuration only)
+	duration durationInput
+
+	// Struct type of nested structs and slice rows (kindStruct and
+	// kindStructSlice), or element type of other slices (kindSlice)
+	elem reflect.Type
+
+	// Kind of the slice elements (kindSlice only)
+	elemKind fieldKind
+
+	// Whether slice rows are pointers, and the min/max row counts (kindStructSlice only)
+	elemPointer bool
+	minRows     int
+	maxRows     int
+
+	// Go path of the embedded structs a promoted field belongs to (Audit.),
+	// as reported in validation error namespaces
+	embed string
+
+	// Whether an embedded struct pointer lies on the index path
+	embedPointer bool
+}
+
+// schemaCache maps reflect.Type to *typeSchema
+var schemaCache sync.Map
+
+// schemaFor returns the cached schema of a struct type, compiling it on first use
+func schemaFor(typ reflect.Type) *typeSchema {
+	return compiledSchema(typ, nil)
+}
+
+// compiledSchema returns the cached schema of a struct type nested in the
+// struct types being compiled
+func compiledSchema(typ reflect.Type, parents []reflect.Type) *typeSchema {
+	if cached, ok := schemaCache.Load(typ); ok {
+		return cached.(*typeSchema)
+	}
+	cached, _ := schemaCache.LoadOrStore(typ, compileSchema(typ, parents))
+	return cached.(*typeSchema)
+}
+
+// compileSchema parses the tags of all fields of a struct type and validates
+// the structural rules that do not depend on field values, including those of
+// nested structs
+func compileSchema(typ reflect.Type, parents []reflect.Type) *typeSchema {
+	schema := &typeSchema{typ: typ}
+
+	fields := collectFields(typ)
+	for _, fs := range fields {
+		if !fs.config.Skip {
+			schema.fields = append(schema.fields, fs)
+		}
+	}
+
+	// Validate hidden field restrictions before other validations
+	for i := range schema.fields {
+		if err := validateHidden(&schema.fields[i]); err != nil {
+			schema.renderErr = err
+			break
+		}
+	}
+
+	// Validate Choices/Chosen pairs
+	pairs, err := pairChoicesChosen(fields)
+	if err != nil {
+		schema.err = err
+		return schema
+	}
+	schema.pairs = pairs
+
+	// Resolve field kinds
+	for i := range schema.fields {
+		fs := &schema.fields[i]
+		name := fs.field.Name
+
+		if strings.HasSuffix(name, "Choices") {
+			fs.kind = kindChoices
+			continue
+		}
+		if strings.HasSuffix(name, "Chosen") {
+			fs.kind = kindChosen
+			for p := range pairs {
+				if pairs[p].ChosenField.Name == name {
+					fs.pair = p
+				}
+			}
+			continue
+		}
+
+		actualType := fs.field.Type
+		if actualType.Kind() == reflect.Ptr {
+			fs.pointer = true
+			actualType = actualType.Elem()
+		}
+		fs.kind = resolveKind(actualType)
+		switch {
+		case fs.kind == kindCodec:
+			fs.codec = codecFor(actualType)
+			continue
+		case fs.kind == kindFile || fs.kind == kindFiles:
+			// Files are only bound to *multipart.FileHeader and []*multipart.FileHeader
+			if fs.pointer != (fs.kind == kindFile) {
+				fs.kind = kindUnsupported
+			}
+			fs.maxSize, _ = parseByteSize(fs.config.Attributes["maxsize"])
+			continue
+		case fs.kind == kindTime:
+			if tz, ok := fs.config.Attributes["tz"]; ok {
+				loc, err := time.LoadLocation(tz)
+				if err != nil && schema.err == nil {
+					schema.err = structError(ErrTimeZone, name, "vee: unknown time zone '%s' for field '%s'", tz, name)
+				}
+				fs.location = loc
+			}
+			fs.time = compileTimeInput(fs.config)
+			continue
+		case fs.kind == kindDuration:
+			fs.duration = compileDurationInput(fs.config)
+			continue
+		case fs.kind == kindSlice:
+			// Slices of values are only rendered and bound as hidden fields
+			fs.elem = actualType.Elem()
+			fs.elemKind = resolveKind(fs.elem)
+			continue
+		case fs.kind == kindStruct:
+			fs.elem = actualType
+		case fs.kind == kindStructSlice && !fs.pointer:
+			fs.elem = actualType.Elem()
+			if fs.elem.Kind() == reflect.Ptr {
+				fs.elemPointer = true
+				fs.elem = fs.elem.Elem()
+			}
+			fs.minRows, _ = strconv.Atoi(fs.config.Attributes["min"])
+			fs.maxRows, _ = strconv.Atoi(fs.config.Attributes["max"])
+		default:
+			// Pointers to slices are not supported
+			if fs.kind == kindStructSlice {
+				fs.kind = kindUnsupported
+			}
+			continue
+		}
+
+		// Report structural errors of nested structs with the parent. Recursive
+		// types are checked when their outermost struct is compiled.
+		if fs.elem == typ || slices.Contains(parents, fs.elem) {
+			continue
+		}
+		nested := compiledSchema(fs.elem, append(parents, typ))
+		if schema.err == nil {
+			schema.err = nested.err
+		}
+		if schema.renderErr == nil {
+			schema.renderErr = nested.renderErr
+		}
+	}
+
+	return schema
+}
+
+// embeddedStruct is a struct type embedded at some depth of a struct type
+type embeddedStruct struct {
+	typ     reflect.Type
+	index   []int
+	path    string // Go path of the embedded fields (Audit.)
+	pointer bool   // Whether a struct pointer lies on the index path
+}
+
+// collectFields returns the fields of a struct type including those promoted
+// from embedded structs, following the rules of encoding/json: embedded
+// structs without a $name override are flattened, and among fields with the
+// same HTML name the shallowest wins, then the one with a $name override; any
+// other conflict drops all of them. Fields skipped with vee:"-" are included
+// (with config.Skip set) so Choices/Chosen pairing sees them, but embedded
+// structs skipped with vee:"-" are dropped entirely.
+func collectFields(typ reflect.Type) []fieldSchema {
+	type candidate struct {
+		fieldSchema
+		depth  int
+		tagged bool
+	}
+
+	var candidates []candidate
+	visited := map[reflect.Type]bool{}
+	next := []embeddedStruct{{typ: typ}}
+	for depth := 0; len(next) > 0; depth++ {
+		current := next
+		next = nil
+
+		for _, embedded := range current {
+			// Types embedded at shallower depths already promoted their fields
+			if visited[embedded.typ] {
+				continue
+			}
+
+			for i := 0; i < embedded.typ.NumField(); i++ {
+				field := embedded.typ.Field(i)
+				fieldType := field.Type
+				if fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+
+				// Unexported embedded structs still promote their exported fields
+				if field.Anonymous {
+					if !field.IsExported() && fieldType.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !field.IsExported() {
+					continue
+				}
+
+				tag := field.Tag.Get("vee")
+				config := parseVeeTag(tag, field.Name)
+				field.Index = append(slices.Clone(embedded.index), i)
+
+				// Flatten embedded structs unless they are named with $name
+				tagged := strings.HasPrefix(tag, "$")
+				if field.Anonymous && fieldType.Kind() == reflect.Struct && fieldType != timeType && codecFor(fieldType) == nil && !tagged {
+					if !config.Skip {
+						next = append(next, embeddedStruct{
+							typ:     fieldType,
+							index:   field.Index,
+							path:    embedded.path + field.Name + ".",
+							pointer: embedded.pointer || field.Type.Kind() == reflect.Ptr,
+						})
+					}
+					continue
+				}
+				if !field.IsExported() {
+					continue
+				}
+
+				candidates = append(candidates, candidate{
+					fieldSchema: fieldSchema{
+						field:        field,
+						config:       config,
+						label:        generateLabel(config, field.Name),
+						css:          field.Tag.Get("css"),
+						labelCSS:     field.Tag.Get("labelCss"),
+						errorCSS:     field.Tag.Get("errorCss"),
+						embed:        embedded.path,
+						embedPointer: embedded.pointer,
+					},
+					depth:  depth,
+					tagged: tagged,
+				})
+			}
+		}
+
+		for _, embedded := range current {
+			visited[embedded.typ] = true
+		}
+	}
+
+	// Resolve fields with conflicting HTML names
+	byName := map[string][]int{}
+	for i, c := range candidates {
+		if !c.config.Skip {
+			byName[c.config.Name] = append(byName[c.config.Name], i)
+		}
+	}
+	dropped := make([]bool, len(candidates))
+	for _, indices := range byName {
+		if len(indices) == 1 {
+			continue
+		}
+
+		// Keep the only shallowest field, or the only tagged one among them
+		depth := candidates[indices[0]].depth
+		for _, i := range indices {
+			depth = min(depth, candidates[i].depth)
+		}
+		var shallowest, tagged []int
+		for _, i := range indices {
+			if candidates[i].depth == depth {
+				shallowest = append(shallowest, i)
+				if candidates[i].tagged {
+					tagged = append(tagged, i)
+				}
+			}
+		}
+		dominant := -1
+		if len(shallowest) == 1 {
+			dominant = shallowest[0]
+		} else if len(tagged) == 1 {
+			dominant = tagged[0]
+		}
+		for _, i := range indices {
+			dropped[i] = i != dominant
+		}
+	}
+
+	fields := make([]fieldSchema, 0, len(candidates))
+	for i, c := range candidates {
+		if !dropped[i] {
+			fields = append(fields, c.fieldSchema)
+		}
+	}
+
+	// Promoted fields take the position of their embedded struct
+	slices.SortStableFunc(fields, func(a, b fieldSchema) int {
+		return slices.Compare(a.field.Index, b.field.Index)
+	})
+	return fields
+}
+
+// value returns the value of the field in the struct value val, or its zero
+// value if an embedded struct pointer on the way is nil
+func (fs *fieldSchema) value(val reflect.Value) reflect.Value {
+	return fieldValue(val, fs.field)
+}
+
+// fieldValue returns the value of a possibly promoted field in the struct
+// value val, or its zero value if an embedded struct pointer on the way is nil
+func fieldValue(val reflect.Value, field reflect.StructField) reflect.Value {
+	if len(field.Index) == 1 {
+		return val.Field(field.Index[0])
+	}
+	fieldVal, err := val.FieldByIndexErr(field.Index)
+	if err != nil {
+		return reflect.Zero(field.Type)
+	}
+	return fieldVal
+}
+
+// settableValue returns the value of the field in the struct value val,
+// allocating nil embedded struct pointers on the way. It returns an invalid
+// value if a pointer cannot be allocated (pointers to unexported structs).
+func (fs *fieldSchema) settableValue(val reflect.Value) reflect.Value {
+	for i, index := range fs.field.Index {
+		if i > 0 && val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				if !val.CanSet() {
+					return reflect.Value{}
+				}
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+		val = val.Field(index)
+	}
+	return val
+}
+
+// resolveKind determines the fieldKind of a non-pointer type
+func resolveKind(typ reflect.Type) fieldKind {
+	// Registered types take precedence over everything else
+	if codecFor(typ) != nil {
+		return kindCodec
+	}
+
+	// Check for specific types first (before generic kind matching)
+	switch typ {
+	case timeType:
+		return kindTime
+	case durationType:
+		return kindDuration
+	case fileType:
+		return kindFile
+	}
+
+	// Types with their own text encoding take precedence over their kind
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return kindText
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return kindString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return kindUint
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	case reflect.Bool:
+		return kindBool
+	case reflect.Struct:
+		return kindStruct
+	case reflect.Slice:
+		// Slices of structs or struct pointers are rendered as repeatable rows
+		elem := typ.Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+			if elem == fileType {
+				return kindFiles
+			}
+		}
+		switch kind := resolveKind(elem); {
+		case kind == kindStruct:
+			return kindStructSlice
+		case elem == typ.Elem() && hiddenKind(kind):
+			return kindSlice
+		}
+		return kindUnsupported
+	default:
+		return kindUnsupported
+	}
+}
+
+// validateHidden validates the hidden field restrictions of a field: hidden
+// fields hold values supported by the hidden value codec, pointers to them,
+// slices of them, or the indices of a Chosen field
+func validateHidden(fs *fieldSchema) error {
+	if !fs.config.Hidden {
+		return nil
+	}
+	field := fs.field
+
+	// Choices are rendered as the options of their Chosen field
+	if strings.HasSuffix(field.Name, "Choices") {
+		return structError(ErrUnsupportedHidden, field.Name, "vee: hidden attribute not supported for multi-value field '%s'", field.Name)
+	}
+	if strings.HasSuffix(field.Name, "Chosen") {
+		return nil
+	}
+
+	typ := field.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	kind := resolveKind(typ)
+	switch {
+	case typ.Kind() == reflect.Array || kind == kindSlice && field.Type.Kind() == reflect.Ptr:
+		return structError(ErrUnsupportedHidden, field.Name, "vee: hidden attribute not supported for slice/array type '%s'", field.Name)
+	case kind != kindSlice && !hiddenKind(kind):
+		return structError(ErrUnsupportedHidden, field.Name, "vee: hidden attribute not supported for type '%s' of field '%s'", field.Type, field.Name)
+	case kind == kindCodec && !hiddenCodec(typ), kind == kindSlice && resolveKind(typ.Elem()) == kindCodec && !hiddenCodec(typ.Elem()):
+		return structError(ErrUnsupportedHidden, field.Name, "vee: hidden attribute not supported for type '%s' of field '%s': its codec writes its own control without implementing HiddenCodec", field.Type, field.Name)
+	}
+
+	return nil
+}
+
+// byteSizeUnits are the suffixes accepted by parseByteSize
+var byteSizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// parseByteSize parses a size in bytes with an optional KB, MB or GB suffix
+// (powers of 1024), e.g. "512", "100KB" or "5MB"
+func parseByteSize(s string) (int64, error) {
+	size := int64(1)
+	for _, unit := range byteSizeUnits {
+		if number, ok := strings.CutSuffix(s, unit.suffix); ok {
+			s, size = strings.TrimSpace(number), unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 || n > math.MaxInt64/size {
+		return 0, fmt.Errorf("vee: invalid size %q", s)
+	}
+	return n * size, nil
+}
+
+// structValue resolves v to a struct value and its cached schema
+func structValue(v any) (reflect.Value, *typeSchema, error) {
+	val := reflect.ValueOf(v)
+	typ := reflect.TypeOf(v)
+
+	if typ == nil {
+		return reflect.Value{}, nil, structError(ErrNotStruct, "", "vee: expected struct, got nil")
+	}
+
+	// Handle pointer to struct
+	if typ.Kind() == reflect.Ptr {
+		val = val.Elem()
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return reflect.Value{}, nil, structError(ErrNotStruct, "", "vee: expected struct, got %v", typ.Kind())
+	}
+
+	return val, schemaFor(typ), nil
+}
+
+// pairValues returns a Choices/Chosen pair with the field values of the given struct value
+func (schema *typeSchema) pairValues(p int, val reflect.Value) ChoicesChosenPair {
+	pair := schema.pairs[p]
+	pair.ChoicesValue = fieldValue(val, pair.ChoicesField)
+	pair.ChosenValue = fieldValue(val, pair.ChosenField)
+	return pair
+}
+
+// choicePairs returns the Choices/Chosen pairs with the field values of the
+// given struct value and the choices of their providers, validating the
+// values of the pairs marked in validated
+func (schema *typeSchema) choicePairs(ctx context.Context, val reflect.Value, validated []bool) ([]ChoicesChosenPair, error) {
+	pairs := make([]ChoicesChosenPair, len(schema.pairs))
+	for p := range schema.pairs {
+		if err := schema.resolvePair(ctx, val, pairs, p, validated[p]); err != nil {
+			return nil, err
+		}
+	}
+	return pairs, nil
+}
+
+// resolvePair sets pairs[p] to the pair with the field values of the given
+// struct value and the choices of its provider for the choice of its parent,
+// which must be resolved already, and validates its values if validate is set
+func (schema *typeSchema) resolvePair(ctx context.Context, val reflect.Value, pairs []ChoicesChosenPair, p int, validate bool) error {
+	pairs[p] = schema.pairValues(p, val)
+	var parent *ChoicesChosenPair
+	if pairs[p].parent >= 0 {
+		parent = &pairs[pairs[p].parent]
+	}
+	if err := pairs[p].provideChoices(ctx, val, parent); err != nil {
+		return err
+	}
+	if validate {
+		return validateChoicesValues(pairs[p])
+	}
+	return nil
+}
+
+// validatedPairs reports which pairs of the struct in scope have their values
+// validated: none in zero value rows, only the pairs the Chosen field with
+// the Go field path only depends on if only is set, and all others otherwise
+func (schema *typeSchema) validatedPairs(scope fieldScope, only string) []bool {
+	validated := make([]bool, len(schema.pairs))
+	if scope.zero {
+		return validated
+	}
+	for p, pair := range schema.pairs {
+		if only == "" {
+			validated[p] = true
+			continue
+		}
+		if scope.fieldPath(pair.ChosenField.Name) != only {
+			continue
+		}
+		for ; p >= 0; p = schema.pairs[p].parent {
+			validated[p] = true
+		}
+	}
+	return validated
+}
+
+// fieldScope qualifies the names of fields inside nested structs
+type fieldScope struct {
+	sep   string // Separator between HTML name and id segments
+	name  string // HTML name of the enclosing struct field
+	id    string // HTML id of the enclosing struct field
+	field string // Go field path of the enclosing struct field
+
+	// Whether the fields belong to a zero value slice row (the row template or
+	// a row added on the client) whose Choices may be empty
+	zero bool
+}
+
+// fieldName returns the qualified HTML name of a field
+func (scope fieldScope) fieldName(name string) string {
+	if scope.name == "" {
+		return name
+	}
+	return scope.name + scope.sep + name
+}
+
+// fieldID returns the qualified HTML id of a field
+func (scope fieldScope) fieldID(id string) string {
+	if scope.id == "" {
+		return id
+	}
+	return scope.id + scope.sep + id
+}
+
+// fieldPath returns the Go field path of a field (e.g. Address.Street)
+func (scope fieldScope) fieldPath(name string) string {
+	if scope.field == "" {
+		return name
+	}
+	return scope.field + "." + name
+}
+
+// nest returns the scope of the fields of a nested struct field
+func (scope fieldScope) nest(name, id, path string) fieldScope {
+	return fieldScope{sep: scope.sep, name: name, id: id, field: path, zero: scope.zero}
+}
+
+// row returns the scope of the fields of a slice row with the given index
+func (scope fieldScope) row(name, id, path, index string, zero bool) fieldScope {
+	return fieldScope{
+		sep:   scope.sep,
+		name:  name + "[" + index + "]",
+		id:    id + "_" + index,
+		field: path + "[" + index + "]",
+		zero:  zero,
+	}
+}
@@ -0,0 +1,108 @@
+package vee
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSchemaCached(t *testing.T) {
+	type Form struct {
+		Name         string `vee:"$full_name" css:"input"`
+		Age          *int
+		Secret       string `vee:"-"`
+		internal     string
+		ColorChoices []string
+		ColorChosen  int
+	}
+
+	typ := reflect.TypeOf(Form{})
+	schema := schemaFor(typ)
+	if again := schemaFor(typ); again != schema {
+		t.Error("schemaFor() returned a different schema for the same type")
+	}
+
+	want := []struct {
+		name    string
+		kind    fieldKind
+		pointer bool
+	}{
+		{"Name", kindString, false},
+		{"Age", kindInt, true},
+		{"ColorChoices", kindChoices, false},
+		{"ColorChosen", kindChosen, false},
+	}
+	if len(schema.fields) != len(want) {
+		t.Fatalf("schema has %d fields, want %d", len(schema.fields), len(want))
+	}
+	for i, w := range want {
+		fs := schema.fields[i]
+		if fs.field.Name != w.name || fs.kind != w.kind || fs.pointer != w.pointer {
+			t.Errorf("fields[%d] = {%s %v %v}, want {%s %v %v}", i, fs.field.Name, fs.kind, fs.pointer, w.name, w.kind, w.pointer)
+		}
+	}
+	if schema.fields[0].config.Name != "full_name" || schema.fields[0].css != "input" {
+		t.Errorf("fields[0] = %+v, want name 'full_name' and css 'input'", schema.fields[0])
+	}
+	if len(schema.pairs) != 1 || schema.pairs[0].ChosenField.Name != "ColorChosen" {
+		t.Errorf("pairs = %+v, want ColorChoices/ColorChosen", schema.pairs)
+	}
+}
+
+func TestSchemaStructuralErrors(t *testing.T) {
+	type Orphan struct {
+		ColorChosen int
+	}
+	type HiddenArray struct {
+		IDs [2]int `vee:"hidden"`
+	}
+
+	schema := schemaFor(reflect.TypeOf(Orphan{}))
+	if !errors.Is(schema.err, ErrChoicesChosen) {
+		t.Errorf("schema.err = %v, want ErrChoicesChosen", schema.err)
+	}
+
+	// Hidden restrictions only apply to rendering
+	schema = schemaFor(reflect.TypeOf(HiddenArray{}))
+	if !errors.Is(schema.renderErr, ErrUnsupportedHidden) || schema.err != nil {
+		t.Errorf("schema errors = (%v, %v), want (ErrUnsupportedHidden, nil)", schema.renderErr, schema.err)
+	}
+	if _, err := Render(HiddenArray{}); !errors.Is(err, ErrUnsupportedHidden) {
+		t.Errorf("Render() error = %v, want ErrUnsupportedHidden", err)
+	}
+}
+
+func TestSchemaConcurrent(t *testing.T) {
+	type Form struct {
+		Name         string
+		Count        int
+		SizeChoices  []string
+		SizeChosen   int
+		Enabled      bool
+		Ratio        float64
+		Description  string `vee:"placeholder:'Describe'"`
+		Notification bool
+	}
+
+	want, err := Render(Form{SizeChoices: []string{"S", "M"}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			form := Form{SizeChoices: []string{"S", "M"}}
+			if got, err := Render(form); err != nil || got != want {
+				t.Errorf("concurrent Render() = %q, %v", got, err)
+			}
+			if err := Bind(map[string][]string{"count": {"3"}, "size_chosen": {"1"}}, &form); err != nil {
+				t.Errorf("concurrent Bind() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -21,6 +21,7 @@ type FieldConfig struct {
 //   - vee:"$override_name" to override field name
 //   - vee:"" to use auto-derived field name
 //   - vee:"min:10,max:100,step:5" for numeric attributes
+//   - vee:"accept:'.pdf,.txt'" for values containing commas
 func parseVeeTag(tag, fieldName string) FieldConfig {
 	config := FieldConfig{
 		Attributes: make(map[string]string),
@@ -40,8 +41,8 @@ func parseVeeTag(tag, fieldName string) FieldConfig {
 	// Default behavior: process all fields with auto-derived name
 	config.Name = strcase.ToSnake(fieldName)
 
-	// Split by comma
-	parts := strings.Split(tag, ",")
+	// Split by comma, keeping quoted values such as accept:'.pdf,.txt' intact
+	parts := splitTag(tag)
 
 	// Check if first part is name override
 	if strings.HasPrefix(parts[0], "$") {
@@ -84,3 +85,22 @@ func parseVeeTag(tag, fieldName string) FieldConfig {
 
 	return config
 }
+
+// splitTag splits a tag at commas outside of single-quoted values
+func splitTag(tag string) []string {
+	var parts []string
+	start := 0
+	quoted := false
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				parts = append(parts, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, tag[start:])
+}
@@ -51,6 +51,16 @@ func TestParseVeeTag(t *testing.T) {
 	}
 }
 
+func TestParseVeeTagQuotedCommas(t *testing.T) {
+	got := parseVeeTag("accept:'.pdf, .txt',label:'Name, first',required", "File")
+	if got.Attributes["accept"] != ".pdf, .txt" || got.Attributes["label"] != "Name, first" {
+		t.Errorf("parseVeeTag() attributes = %v, want quoted values kept intact", got.Attributes)
+	}
+	if _, ok := got.Attributes["required"]; !ok {
+		t.Errorf("parseVeeTag() attributes = %v, want required", got.Attributes)
+	}
+}
+
 func TestStrCaseConversion(t *testing.T) {
 	tests := []struct {
 		input string
@@ -0,0 +1,72 @@
+package vee
+
+import (
+	"html/template"
+)
+
+// TemplateForm pairs a struct with render options that apply only to it,
+// such as the errors of the current request.
+type TemplateForm struct {
+	Value   any
+	Options []RenderOption
+}
+
+// WithOptions wraps a struct for use with the FuncMap template functions.
+//
+// Example:
+//
+//	data := map[string]any{"Form": vee.WithOptions(user, vee.ErrorsOption(err))}
+func WithOptions(v any, opts ...RenderOption) TemplateForm {
+	return TemplateForm{Value: v, Options: opts}
+}
+
+// FuncMap returns html/template functions for rendering forms and individual
+// fields. The given options apply to every call; options passed with
+// WithOptions are applied after them.
+//
+//	{{ veeForm .Form }}              the whole form, like Render
+//	{{ veeFields .Form }}            all fields without the form wrapper, like RenderFields
+//	{{ veeField "Email" .Form }}     label, input, help text and errors of a field
+//	{{ veeLabel "Email" .Form }}     the label of a field
+//	{{ veeInput "Email" .Form }}     the input of a field
+//	{{ veeErrors "Email" .Form }}    the error messages of a field
+//
+// Fields are referenced by their Go struct field name. The form argument can
+// be a struct, a pointer to a struct or a TemplateForm.
+func FuncMap(opts ...RenderOption) template.FuncMap {
+	return template.FuncMap{
+		"veeForm":   templateFormFunc(opts, Render),
+		"veeFields": templateFormFunc(opts, RenderFields),
+		"veeField":  templateFieldFunc(opts, RenderField),
+		"veeLabel":  templateFieldFunc(opts, RenderLabel),
+		"veeInput":  templateFieldFunc(opts, RenderInput),
+		"veeErrors": templateFieldFunc(opts, RenderErrors),
+	}
+}
+
+// templateFormFunc adapts a form render function for use in templates
+func templateFormFunc(opts []RenderOption, render func(any, ...RenderOption) (string, error)) func(any) (template.HTML, error) {
+	return func(v any) (template.HTML, error) {
+		value, options := templateOptions(v, opts)
+		html, err := render(value, options...)
+		return template.HTML(html), err
+	}
+}
+
+// templateFieldFunc adapts a field render function for use in templates
+func templateFieldFunc(opts []RenderOption, render func(any, string, ...RenderOption) (string, error)) func(string, any) (template.HTML, error) {
+	return func(name string, v any) (template.HTML, error) {
+		value, options := templateOptions(v, opts)
+		html, err := render(value, name, options...)
+		return template.HTML(html), err
+	}
+}
+
+// templateOptions unwraps a TemplateForm and combines its options with the FuncMap options
+func templateOptions(v any, opts []RenderOption) (any, []RenderOption) {
+	form, ok := v.(TemplateForm)
+	if !ok {
+		return v, opts
+	}
+	return form.Value, append(append([]RenderOption{}, opts...), form.Options...)
+}
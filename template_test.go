@@ -0,0 +1,69 @@
+package vee
+
+import (
+	"errors"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestFuncMap(t *testing.T) {
+	type User struct {
+		Name  string `validate:"required"`
+		Email string `vee:"type:'email'" css:"input"`
+	}
+
+	tmpl := template.Must(template.New("page").Funcs(FuncMap(InputCSSOption("default"))).Parse(
+		`<h1>Account</h1>{{ veeForm .Form }}<hr>{{ veeLabel "Name" .Form }}{{ veeField "Email" .Form }}{{ veeErrors "Name" .Form }}`,
+	))
+
+	user := User{Email: "john@example.com"}
+	var out strings.Builder
+	err := tmpl.Execute(&out, map[string]any{
+		"Form": WithOptions(user, ErrorsOption(Validate(user))),
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	form, err := Render(user, InputCSSOption("default"), ErrorsOption(Validate(user)))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `<h1>Account</h1>` + form + `<hr>` +
+		`<label for="name">Name</label>` + "\n" +
+		`<label for="email">Email</label>` + "\n" +
+		`<input type="email" name="email" value="john@example.com" class="input" id="email">` + "\n" +
+		`<span id="name_error">This field is required</span>` + "\n"
+	if out.String() != want {
+		t.Errorf("Execute() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestFuncMapPlainStruct(t *testing.T) {
+	type User struct {
+		Name string
+	}
+
+	tmpl := template.Must(template.New("page").Funcs(FuncMap()).Parse(`{{ . | veeField "Name" }}{{ veeErrors "Name" . }}`))
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, &User{Name: "<John>"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := "<label for=\"name\">Name</label>\n<input type=\"text\" name=\"name\" value=\"&lt;John&gt;\" id=\"name\">\n"
+	if out.String() != want {
+		t.Errorf("Execute() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestFuncMapUnknownField(t *testing.T) {
+	tmpl := template.Must(template.New("page").Funcs(FuncMap()).Parse(`{{ veeField "Missing" . }}`))
+
+	err := tmpl.Execute(&strings.Builder{}, struct{ Name string }{})
+	if !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("Execute() error = %v, want ErrFieldNotFound", err)
+	}
+}
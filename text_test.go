@@ -0,0 +1,137 @@
+package vee
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// textUserID is encoded as "usr_<n>"
+type textUserID int
+
+func (id textUserID) MarshalText() ([]byte, error) {
+	return []byte("usr_" + strconv.Itoa(int(id))), nil
+}
+
+func (id *textUserID) UnmarshalText(text []byte) error {
+	n, ok := strings.CutPrefix(string(text), "usr_")
+	if !ok {
+		return fmt.Errorf("invalid user id %q", text)
+	}
+	v, err := strconv.Atoi(n)
+	*id = textUserID(v)
+	return err
+}
+
+// textLocale has pointer receivers on both methods
+type textLocale struct {
+	Lang, Region string
+}
+
+func (l *textLocale) MarshalText() ([]byte, error) {
+	return []byte(l.Lang + "-" + l.Region), nil
+}
+
+func (l *textLocale) UnmarshalText(text []byte) error {
+	lang, region, ok := strings.Cut(string(text), "-")
+	if !ok {
+		return errors.New("invalid locale")
+	}
+	l.Lang, l.Region = lang, region
+	return nil
+}
+
+// textSlug only implements UnmarshalText
+type textSlug string
+
+func (s *textSlug) UnmarshalText(text []byte) error {
+	*s = textSlug(strings.ToLower(string(text)))
+	return nil
+}
+
+type textForm struct {
+	Owner  textUserID
+	Locale textLocale
+	Slug   textSlug
+	Parent *textUserID
+	Ref    textUserID `vee:"hidden"`
+}
+
+func TestTextMarshalerRendering(t *testing.T) {
+	parent := textUserID(7)
+	form := textForm{
+		Owner:  42,
+		Locale: textLocale{Lang: "en", Region: "GB"},
+		Slug:   "hello-world",
+		Parent: &parent,
+		Ref:    3,
+	}
+
+	got, err := Render(form)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := []string{
+		`<input type="text" name="owner" value="usr_42" id="owner">`,
+		`<input type="text" name="locale" value="en-GB" id="locale">`,
+		`<input type="text" name="slug" value="hello-world" id="slug">`,
+		`<input type="text" name="parent" value="usr_7" id="parent">`,
+		`<input type="hidden" name="ref" value="usr_3" id="ref">`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, got)
+		}
+	}
+	if strings.Contains(got, "<fieldset") {
+		t.Errorf("Render() = %s, want text marshaling structs rendered as inputs", got)
+	}
+
+	got, err = Render(textForm{})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(got, `<input type="text" name="parent" value="" id="parent">`) {
+		t.Errorf("Render() = %s, want empty value for nil pointer", got)
+	}
+}
+
+func TestTextUnmarshalerBinding(t *testing.T) {
+	var form textForm
+	err := Bind(map[string][]string{
+		"owner":  {"usr_42"},
+		"locale": {"de-AT"},
+		"slug":   {"Hello-World"},
+		"parent": {"usr_7"},
+		"ref":    {"usr_3"},
+	}, &form)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	if form.Owner != 42 || form.Locale != (textLocale{"de", "AT"}) || form.Slug != "hello-world" || form.Ref != 3 {
+		t.Errorf("Bind() = %+v, want unmarshaled values", form)
+	}
+	if form.Parent == nil || *form.Parent != 7 {
+		t.Errorf("Bind() Parent = %v, want 7", form.Parent)
+	}
+}
+
+func TestTextUnmarshalerErrors(t *testing.T) {
+	form := textForm{Owner: 1}
+	err := Bind(map[string][]string{"owner": {"42"}, "parent": {"nope"}}, &form)
+
+	var bindErrs BindErrors
+	if !errors.As(err, &bindErrs) || len(bindErrs) != 2 {
+		t.Fatalf("Bind() error = %v, want two BindErrors entries", err)
+	}
+	if bindErrs[0].Field != "Owner" || bindErrs[0].Kind != KindText || bindErrs[0].Message() != "Invalid value" {
+		t.Errorf("FieldError = %+v, want text error for Owner", bindErrs[0])
+	}
+	if form.Owner != 1 || form.Parent != nil {
+		t.Errorf("Bind() = %+v, want fields unchanged", form)
+	}
+}
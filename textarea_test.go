@@ -0,0 +1,155 @@
+package vee
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextareaRendering(t *testing.T) {
+	bio := "Hello"
+
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name: "textarea with attributes",
+			input: struct {
+				Notes string `vee:"type:'textarea',rows:5,cols:40,maxlength:500,wrap:'hard',required" css:"h-24"`
+			}{Notes: "Line 1\nLine 2"},
+			want: `<form method="POST">
+<label for="notes">Notes</label>
+<textarea name="notes" rows="5" cols="40" maxlength="500" wrap="hard" class="h-24" id="notes" required>Line 1
+Line 2</textarea>
+</form>
+`,
+		},
+		{
+			name: "textarea content is escaped",
+			input: struct {
+				Notes string `vee:"type:'textarea'"`
+			}{Notes: `</textarea><script>alert("x") & more</script>`},
+			want: `<form method="POST">
+<label for="notes">Notes</label>
+<textarea name="notes" id="notes">&lt;/textarea&gt;&lt;script&gt;alert(&quot;x&quot;) &amp; more&lt;/script&gt;</textarea>
+</form>
+`,
+		},
+		{
+			name: "leading newline is preserved",
+			input: struct {
+				Notes string `vee:"type:'textarea'"`
+			}{Notes: "\nindented"},
+			want: `<form method="POST">
+<label for="notes">Notes</label>
+<textarea name="notes" id="notes">
+
+indented</textarea>
+</form>
+`,
+		},
+		{
+			name: "invalid wrap is ignored",
+			input: struct {
+				Notes string `vee:"type:'textarea',wrap:'sometimes'"`
+			}{},
+			want: `<form method="POST">
+<label for="notes">Notes</label>
+<textarea name="notes" id="notes"></textarea>
+</form>
+`,
+		},
+		{
+			name: "string pointer",
+			input: struct {
+				Bio *string `vee:"type:'textarea',placeholder:'Tell us about yourself'"`
+			}{Bio: &bio},
+			want: `<form method="POST">
+<label for="bio">Bio</label>
+<textarea name="bio" id="bio" placeholder="Tell us about yourself">Hello</textarea>
+</form>
+`,
+		},
+		{
+			name: "nil string pointer",
+			input: struct {
+				Bio *string `vee:"type:'textarea'"`
+			}{},
+			want: `<form method="POST">
+<label for="bio">Bio</label>
+<textarea name="bio" id="bio"></textarea>
+</form>
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.input)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextareaBinding(t *testing.T) {
+	type Form struct {
+		Notes string  `vee:"type:'textarea'"`
+		Bio   *string `vee:"type:'textarea'"`
+		Title string
+	}
+
+	var form Form
+	err := Bind(map[string][]string{
+		"notes": {"Line 1\r\nLine 2\rLine 3\n"},
+		"bio":   {"\r\nHello\r\n"},
+		"title": {"Keep\r\nCR"},
+	}, &form)
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	if form.Notes != "Line 1\nLine 2\nLine 3\n" {
+		t.Errorf("Notes = %q, want normalized newlines", form.Notes)
+	}
+	if form.Bio == nil || *form.Bio != "\nHello\n" {
+		t.Errorf("Bio = %v, want %q", form.Bio, "\nHello\n")
+	}
+	// Only textareas are normalized
+	if form.Title != "Keep\r\nCR" {
+		t.Errorf("Title = %q, want unchanged", form.Title)
+	}
+}
+
+func TestTextareaRoundTrip(t *testing.T) {
+	type Form struct {
+		Notes string `vee:"type:'textarea'"`
+	}
+
+	original := Form{Notes: "\nFirst line\n\n<b>bold</b> & \"quoted\"\n"}
+	html, err := Render(original)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	// Extract the submitted value the way a browser would: drop the newline
+	// after the start tag, unescape, and submit line breaks as CRLF
+	_, body, _ := strings.Cut(html, `id="notes">`)
+	body, _, _ = strings.Cut(body, "</textarea>")
+	body = strings.TrimPrefix(body, "\n")
+	body = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&quot;", `"`, "&amp;", "&").Replace(body)
+	body = strings.ReplaceAll(body, "\n", "\r\n")
+
+	var bound Form
+	if err := Bind(map[string][]string{"notes": {body}}, &bound); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if bound != original {
+		t.Errorf("round trip = %q, want %q", bound.Notes, original.Notes)
+	}
+}
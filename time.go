@@ -0,0 +1,158 @@
+package vee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts maps the HTML input types of time.Time fields to the layouts of
+// their values with minute precision. The week input type has no layout and
+// is handled separately.
+var timeLayouts = map[string]string{
+	"date":           "2006-01-02",
+	"datetime-local": "2006-01-02T15:04",
+	"time":           "15:04",
+	"month":          "2006-01",
+}
+
+// timeInput describes how the values of a time.Time field are rendered and
+// parsed. It is compiled once per field from the type, step and format
+// attributes.
+type timeInput struct {
+	typ     string // HTML input type
+	layout  string // Layout of rendered values, empty for weeks
+	zoned   bool   // Whether values are converted to the time zone of the field
+	seconds bool   // Whether submitted values may have seconds (datetime-local and time)
+	custom  bool   // Whether the layout comes from the format attribute
+}
+
+// compileTimeInput returns the time input of a field. A format attribute
+// renders a text input with that layout, otherwise the type attribute selects
+// the input type (defaulting to datetime-local) and a step below one minute
+// adds seconds, or milliseconds for fractional steps.
+func compileTimeInput(config FieldConfig) timeInput {
+	if format := config.Attributes["format"]; format != "" {
+		return timeInput{typ: "text", layout: format, zoned: hasClock(format), custom: true}
+	}
+
+	in := timeInput{typ: config.Attributes["type"]}
+	if _, ok := timeLayouts[in.typ]; !ok && in.typ != "week" {
+		in.typ = "datetime-local"
+	}
+	in.layout = timeLayouts[in.typ]
+	in.zoned = in.typ == "datetime-local" || in.typ == "time"
+	in.seconds = in.zoned
+
+	if step, ok := config.Attributes["step"]; ok && in.seconds {
+		seconds, err := strconv.ParseFloat(step, 64)
+		switch {
+		case err != nil || seconds != float64(int64(seconds)):
+			// Fractional or "any" steps
+			in.layout += ":05.000"
+		case int64(seconds)%60 != 0:
+			in.layout += ":05"
+		}
+	}
+	return in
+}
+
+// hasClock reports whether a layout contains the time of day
+func hasClock(layout string) bool {
+	midnight := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return midnight.Format(layout) != midnight.Add(13*time.Hour+time.Minute).Format(layout)
+}
+
+// format formats a time as the value of the input, converted to loc if not
+// nil and the input has a time of day
+func (in timeInput) format(t time.Time, loc *time.Location) string {
+	if loc != nil && in.zoned {
+		t = t.In(loc)
+	}
+	if in.typ == "week" {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	}
+	return t.Format(in.layout)
+}
+
+// parse parses a submitted value of the input in loc, or in UTC if loc is
+// nil or the input has no time of day. Values of datetime-local and time
+// inputs are accepted with or without seconds and fractional seconds, and
+// with a space separating the date and time.
+func (in timeInput) parse(value string, loc *time.Location) (time.Time, error) {
+	if in.typ == "week" {
+		return parseWeek(value)
+	}
+	if loc == nil || !in.zoned {
+		loc = time.UTC
+	}
+
+	layout := in.layout
+	if in.seconds {
+		layout = timeLayouts[in.typ]
+		if in.typ == "datetime-local" {
+			value = strings.Replace(value, " ", "T", 1)
+		}
+		if strings.Count(value, ":") == 2 {
+			// Fractional seconds are accepted after the seconds
+			layout += ":05"
+		}
+	}
+	return time.ParseInLocation(layout, value, loc)
+}
+
+// fieldLocation returns the time zone of a time field: its tz attribute, or
+// else the location of the options
+func fieldLocation(fs *fieldSchema, loc *time.Location) *time.Location {
+	if fs.location != nil {
+		return fs.location
+	}
+	return loc
+}
+
+type locationKey struct{}
+
+// WithLocation returns a copy of ctx carrying the time zone of the user, used
+// by BindRequest unless a BindLocationOption is given. Set it in a middleware,
+// and pass LocationFromContext to LocationOption when rendering.
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, loc)
+}
+
+// LocationFromContext returns the time zone stored in ctx by WithLocation, or
+// nil if there is none.
+func LocationFromContext(ctx context.Context) *time.Location {
+	loc, _ := ctx.Value(locationKey{}).(*time.Location)
+	return loc
+}
+
+var errWeek = errors.New("expected week as YYYY-Www")
+
+// parseWeek parses an ISO 8601 week (2024-W05) to the Monday starting it
+func parseWeek(value string) (time.Time, error) {
+	yearText, weekText, ok := strings.Cut(value, "-W")
+	if !ok || len(yearText) != 4 || len(weekText) != 2 {
+		return time.Time{}, errWeek
+	}
+	year, err := strconv.Atoi(yearText)
+	if err != nil {
+		return time.Time{}, errWeek
+	}
+	week, err := strconv.Atoi(weekText)
+	if err != nil || week < 1 {
+		return time.Time{}, errWeek
+	}
+
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	weekday := (int(jan4.Weekday()) + 6) % 7 // Days since Monday
+	monday := jan4.AddDate(0, 0, (week-1)*7-weekday)
+	if _, w := monday.ISOWeek(); w != week {
+		return time.Time{}, fmt.Errorf("week %d does not exist in %d", week, year)
+	}
+	return monday, nil
+}
@@ -1,6 +1,9 @@
 package vee
 
 import (
+	"errors"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -84,7 +87,7 @@ func TestTimeRendering(t *testing.T) {
 			}{Timeout: 30 * time.Second},
 			want: `<form method="POST">
 <label for="timeout">Timeout</label>
-<input type="number" name="timeout" value="30" id="timeout">
+<input type="number" name="timeout" value="30" step="any" id="timeout">
 </form>
 `,
 		},
@@ -95,7 +98,7 @@ func TestTimeRendering(t *testing.T) {
 			}{Timeout: 0},
 			want: `<form method="POST">
 <label for="timeout">Timeout</label>
-<input type="number" name="timeout" id="timeout">
+<input type="number" name="timeout" step="any" id="timeout">
 </form>
 `,
 		},
@@ -106,7 +109,7 @@ func TestTimeRendering(t *testing.T) {
 			}{Delay: 500 * time.Millisecond},
 			want: `<form method="POST">
 <label for="delay">Delay</label>
-<input type="number" name="delay" value="500" id="delay">
+<input type="number" name="delay" value="500" step="any" id="delay">
 </form>
 `,
 		},
@@ -117,7 +120,7 @@ func TestTimeRendering(t *testing.T) {
 			}{Duration: 2*time.Hour + 30*time.Minute},
 			want: `<form method="POST">
 <label for="duration">Duration</label>
-<input type="number" name="duration" value="150" id="duration">
+<input type="number" name="duration" value="150" step="any" id="duration">
 </form>
 `,
 		},
@@ -128,7 +131,7 @@ func TestTimeRendering(t *testing.T) {
 			}{WorkDay: 8 * time.Hour},
 			want: `<form method="POST">
 <label for="work_day">Work Day</label>
-<input type="number" name="work_day" value="8" id="work_day">
+<input type="number" name="work_day" value="8" step="any" id="work_day">
 </form>
 `,
 		},
@@ -150,7 +153,7 @@ func TestTimeRendering(t *testing.T) {
 			}{MaxWait: 250 * time.Millisecond},
 			want: `<form method="POST">
 <label for="max_wait_time">Max Wait</label>
-<input type="number" name="max_wait_time" value="250" id="max_wait_time">
+<input type="number" name="max_wait_time" value="250" step="any" id="max_wait_time">
 </form>
 `,
 		},
@@ -173,7 +176,7 @@ func TestTimeRendering(t *testing.T) {
 <label for="created_at">Created At</label>
 <input type="datetime-local" name="created_at" value="2023-12-01T10:00" id="created_at">
 <label for="expires_in">Expires In</label>
-<input type="number" name="expires_in" value="24" id="expires_in">
+<input type="number" name="expires_in" value="24" step="any" id="expires_in">
 <label for="active">Active</label>
 <input type="checkbox" name="active" value="true" checked id="active">
 </form>
@@ -461,3 +464,204 @@ func TestTimeBinding(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeZones(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	type Appointment struct {
+		Start    time.Time
+		Opens    time.Time `vee:"type:'time'"`
+		Day      time.Time `vee:"type:'date'"`
+		Deadline time.Time `vee:"tz:'America/New_York'"`
+	}
+
+	// 09:30 UTC is 10:30 in Berlin (CET) and 04:30 in New York (EST)
+	instant := time.Date(2024, time.January, 15, 9, 30, 0, 0, time.UTC)
+	day := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	appt := Appointment{Start: instant, Opens: instant, Day: day, Deadline: instant}
+
+	t.Run("render", func(t *testing.T) {
+		got, err := Render(appt, LocationOption(berlin))
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		expected := []string{
+			`<input type="datetime-local" name="start" value="2024-01-15T10:30" id="start">`,
+			`<input type="time" name="opens" value="10:30" id="opens">`,
+			`<input type="date" name="day" value="2024-01-15" id="day">`,
+			`<input type="datetime-local" name="deadline" value="2024-01-15T04:30" id="deadline">`,
+		}
+		for _, exp := range expected {
+			if !strings.Contains(got, exp) {
+				t.Errorf("Expected HTML to contain %q, got:\n%s", exp, got)
+			}
+		}
+	})
+
+	submitted := map[string][]string{
+		"start":    {"2024-01-15T10:30"},
+		"day":      {"2024-01-15"},
+		"deadline": {"2024-01-15T04:30"},
+	}
+
+	t.Run("bind", func(t *testing.T) {
+		var bound Appointment
+		if err := Bind(submitted, &bound, BindLocationOption(berlin)); err != nil {
+			t.Fatalf("Bind() error = %v", err)
+		}
+		if !bound.Start.Equal(instant) || bound.Start.Location() != berlin {
+			t.Errorf("Bind() Start = %v, want %v in Berlin", bound.Start, instant)
+		}
+		if !bound.Deadline.Equal(instant) || bound.Deadline.Location().String() != newYork.String() {
+			t.Errorf("Bind() Deadline = %v, want %v in New York", bound.Deadline, instant)
+		}
+		if !bound.Day.Equal(day) {
+			t.Errorf("Bind() Day = %v, want %v", bound.Day, day)
+		}
+	})
+
+	t.Run("bind request context", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?start=2024-01-15T10:30", nil)
+		req = req.WithContext(WithLocation(req.Context(), berlin))
+
+		var bound Appointment
+		if err := BindRequest(req, &bound); err != nil {
+			t.Fatalf("BindRequest() error = %v", err)
+		}
+		if !bound.Start.Equal(instant) {
+			t.Errorf("BindRequest() Start = %v, want %v", bound.Start, instant)
+		}
+
+		// Options take precedence over the context
+		if err := BindRequest(req, &bound, BindLocationOption(time.UTC)); err != nil {
+			t.Fatalf("BindRequest() error = %v", err)
+		}
+		if want := instant.Add(time.Hour); !bound.Start.Equal(want) {
+			t.Errorf("BindRequest() Start = %v, want %v", bound.Start, want)
+		}
+	})
+
+	t.Run("unknown time zone", func(t *testing.T) {
+		type Form struct {
+			At time.Time `vee:"tz:'Mars/Olympus_Mons'"`
+		}
+		if _, err := Render(Form{}); !errors.Is(err, ErrTimeZone) {
+			t.Errorf("Render() error = %v, want ErrTimeZone", err)
+		}
+		if err := Bind(map[string][]string{}, &Form{}); !errors.Is(err, ErrTimeZone) {
+			t.Errorf("Bind() error = %v, want ErrTimeZone", err)
+		}
+	})
+}
+
+func TestTimeSecondsAndFormats(t *testing.T) {
+	type Form struct {
+		Start    time.Time `vee:"step:1"`
+		Lap      time.Time `vee:"type:'time',step:0.001"`
+		Reminder time.Time `vee:"type:'time',step:300"`
+		Birthday time.Time `vee:"format:'02/01/2006',placeholder:'DD/MM/YYYY'"`
+		Pickup   time.Time `vee:"format:'02/01/2006 3:04 PM'"`
+	}
+
+	form := Form{
+		Start:    time.Date(2024, time.May, 1, 10, 30, 15, 0, time.UTC),
+		Lap:      time.Date(0, time.January, 1, 0, 1, 2, 345_000_000, time.UTC),
+		Reminder: time.Date(0, time.January, 1, 9, 5, 0, 0, time.UTC),
+		Birthday: time.Date(1990, time.December, 24, 0, 0, 0, 0, time.UTC),
+		Pickup:   time.Date(2024, time.May, 1, 14, 30, 0, 0, time.UTC),
+	}
+	got, err := Render(form)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := []string{
+		`<input type="datetime-local" name="start" value="2024-05-01T10:30:15" step="1" id="start">`,
+		`<input type="time" name="lap" value="00:01:02.345" step="0.001" id="lap">`,
+		`<input type="time" name="reminder" value="09:05" step="300" id="reminder">`,
+		`<input type="text" name="birthday" value="24/12/1990" id="birthday" placeholder="DD/MM/YYYY">`,
+		`<input type="text" name="pickup" value="01/05/2024 2:30 PM" id="pickup">`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", exp, got)
+		}
+	}
+
+	tests := []struct {
+		field string
+		value string
+		want  time.Time
+	}{
+		{"start", "2024-05-01T10:30", time.Date(2024, time.May, 1, 10, 30, 0, 0, time.UTC)},
+		{"start", "2024-05-01T10:30:15", time.Date(2024, time.May, 1, 10, 30, 15, 0, time.UTC)},
+		{"start", "2024-05-01T10:30:15.25", time.Date(2024, time.May, 1, 10, 30, 15, 250_000_000, time.UTC)},
+		{"start", "2024-05-01 10:30", time.Date(2024, time.May, 1, 10, 30, 0, 0, time.UTC)},
+		{"lap", "00:01:02.345", time.Date(0, time.January, 1, 0, 1, 2, 345_000_000, time.UTC)},
+		{"reminder", "09:05:30", time.Date(0, time.January, 1, 9, 5, 30, 0, time.UTC)},
+		{"birthday", "24/12/1990", time.Date(1990, time.December, 24, 0, 0, 0, 0, time.UTC)},
+		{"pickup", "01/05/2024 2:30 PM", time.Date(2024, time.May, 1, 14, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		var bound Form
+		if err := Bind(map[string][]string{tt.field: {tt.value}}, &bound); err != nil {
+			t.Errorf("Bind(%s=%q) error = %v", tt.field, tt.value, err)
+			continue
+		}
+		var got time.Time
+		switch tt.field {
+		case "start":
+			got = bound.Start
+		case "lap":
+			got = bound.Lap
+		case "reminder":
+			got = bound.Reminder
+		case "birthday":
+			got = bound.Birthday
+		case "pickup":
+			got = bound.Pickup
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Bind(%s=%q) = %v, want %v", tt.field, tt.value, got, tt.want)
+		}
+	}
+
+	invalid := map[string]string{"start": "2024-05-01T10:30:15:00", "reminder": "9", "birthday": "1990-12-24"}
+	for field, value := range invalid {
+		var bound Form
+		if err := Bind(map[string][]string{field: {value}}, &bound); err == nil {
+			t.Errorf("Bind(%s=%q) error = nil, want error", field, value)
+		}
+	}
+}
+
+func TestTimeFormatZones(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	type Form struct {
+		Day    time.Time `vee:"format:'02.01.2006'"`
+		Pickup time.Time `vee:"format:'02.01.2006 15:04'"`
+	}
+
+	// Date-only formats are calendar values and are not converted
+	form := Form{
+		Day:    time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+		Pickup: time.Date(2024, time.May, 1, 22, 30, 0, 0, time.UTC),
+	}
+	got, err := Render(form, LocationOption(berlin))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(got, `value="01.05.2024"`) || !strings.Contains(got, `value="02.05.2024 00:30"`) {
+		t.Errorf("Render() = %s, want Day unchanged and Pickup in Berlin", got)
+	}
+}
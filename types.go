@@ -1,5 +1,14 @@
 package vee
 
+import (
+	"cmp"
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
 // RenderOption configures form rendering behavior.
 type RenderOption struct {
 	// DefaultInputCSS sets default CSS classes for all input elements
@@ -19,10 +28,65 @@ type RenderOption struct {
 
 	// FormAction sets the action URL for the form
 	FormAction string
+
+	// DefaultErrorCSS sets the CSS classes added to inputs that have errors (defaults to "error")
+	DefaultErrorCSS string
+
+	// ErrorMessageCSS sets CSS classes for the error message element rendered after an input
+	ErrorMessageCSS string
+
+	// Errors holds the error messages to display, keyed by struct field name
+	Errors FieldErrors
+
+	// Renderer writes the form markup (defaults to HTMLRenderer)
+	Renderer Renderer
+
+	// NameSeparator joins the names of nested struct fields (defaults to ".")
+	NameSeparator string
+
+	// Location is the time zone datetime-local and time inputs are rendered
+	// in, unless a field has a tz tag (defaults to the location of the value)
+	Location *time.Location
+
+	// Context is passed to ChoicesProviders (defaults to context.Background)
+	Context context.Context
+
+	// choicesField is the Go field path of the only Chosen field whose
+	// choices are validated, with those it depends on (ChoicesHandler)
+	choicesField string
+}
+
+// BindOption configures form data binding.
+type BindOption struct {
+	// NameSeparator joins the names of nested struct fields (defaults to ".")
+	NameSeparator string
+
+	// Location is the time zone submitted datetime-local and time values are
+	// parsed in, unless a field has a tz tag (defaults to UTC, or the location
+	// of the request context for BindRequest)
+	Location *time.Location
+
+	// MaxMemory is the number of bytes of uploaded files BindRequest keeps in
+	// memory, the rest is stored in temporary files (defaults to 32 MB)
+	MaxMemory int64
+
+	// Context is passed to ChoicesProviders (defaults to context.Background,
+	// or the request context for BindRequest)
+	Context context.Context
+
+	// choicesField is the Go field path of the only Chosen field whose
+	// choices are validated, with those it depends on (ChoicesHandler)
+	choicesField string
 }
 
 const scriptAction = "script"
 
+const defaultErrorCSS = "error"
+
+const defaultNameSeparator = "."
+
+const defaultMaxMemory = 32 << 20
+
 func InputCSSOption(css string) RenderOption {
 	return RenderOption{
 		DefaultInputCSS: css,
@@ -63,13 +127,80 @@ func FormActionScriptOption() RenderOption {
 	return FormActionOption(scriptAction)
 }
 
+func ErrorCSSOption(css string) RenderOption {
+	return RenderOption{
+		DefaultErrorCSS: css,
+	}
+}
+
+func ErrorMessageCSSOption(css string) RenderOption {
+	return RenderOption{
+		ErrorMessageCSS: css,
+	}
+}
+
+// ErrorsOption displays the errors returned by Validate or Bind next to the
+// fields they belong to.
+func ErrorsOption(err error) RenderOption {
+	return RenderOption{
+		Errors: CollectErrors(err),
+	}
+}
+
+// RendererOption renders the form markup with a custom Renderer.
+func RendererOption(renderer Renderer) RenderOption {
+	return RenderOption{
+		Renderer: renderer,
+	}
+}
+
+// NameSeparatorOption joins the names and ids of nested struct fields with sep
+// instead of ".", e.g. "address_street" with "_".
+func NameSeparatorOption(sep string) RenderOption {
+	return RenderOption{
+		NameSeparator: sep,
+	}
+}
+
+// LocationOption renders datetime-local and time inputs in the time zone loc.
+func LocationOption(loc *time.Location) RenderOption {
+	return RenderOption{
+		Location: loc,
+	}
+}
+
+// ContextOption passes ctx to the ChoicesProviders of the rendered struct.
+func ContextOption(ctx context.Context) RenderOption {
+	return RenderOption{
+		Context: ctx,
+	}
+}
+
 func (option RenderOption) IsEqual(other RenderOption) bool {
 	return option.DefaultInputCSS == other.DefaultInputCSS &&
 		option.DefaultLabelCSS == other.DefaultLabelCSS &&
 		option.FormAction == other.FormAction &&
 		option.FormCSS == other.FormCSS &&
 		option.FormID == other.FormID &&
-		option.FormMethod == other.FormMethod
+		option.FormMethod == other.FormMethod &&
+		option.DefaultErrorCSS == other.DefaultErrorCSS &&
+		option.ErrorMessageCSS == other.ErrorMessageCSS &&
+		maps.EqualFunc(option.Errors, other.Errors, slices.Equal) &&
+		equalInterfaces(option.Renderer, other.Renderer) &&
+		option.NameSeparator == other.NameSeparator &&
+		option.Location == other.Location &&
+		equalInterfaces(option.Context, other.Context) &&
+		option.choicesField == other.choicesField
+}
+
+// equalInterfaces reports whether two interface values are equal. Values of
+// uncomparable types, such as renderers with map fields, are compared with
+// reflect.DeepEqual, as comparing them with == panics.
+func equalInterfaces(a, b any) bool {
+	if a == nil || b == nil || !reflect.TypeOf(a).Comparable() {
+		return reflect.DeepEqual(a, b)
+	}
+	return a == b
 }
 
 func (option *RenderOption) apply(other RenderOption) {
@@ -91,6 +222,54 @@ func (option *RenderOption) apply(other RenderOption) {
 	if other.FormAction != "" {
 		option.FormAction = other.FormAction
 	}
+	if other.DefaultErrorCSS != "" {
+		option.DefaultErrorCSS = other.DefaultErrorCSS
+	}
+	if other.ErrorMessageCSS != "" {
+		option.ErrorMessageCSS = other.ErrorMessageCSS
+	}
+	if other.Renderer != nil {
+		option.Renderer = other.Renderer
+	}
+	if other.NameSeparator != "" {
+		option.NameSeparator = other.NameSeparator
+	}
+	if other.Location != nil {
+		option.Location = other.Location
+	}
+	if other.Context != nil {
+		option.Context = other.Context
+	}
+	if other.choicesField != "" {
+		option.choicesField = other.choicesField
+	}
+	for field, messages := range other.Errors {
+		if option.Errors == nil {
+			option.Errors = make(FieldErrors)
+		}
+		option.Errors[field] = append(option.Errors[field], messages...)
+	}
+}
+
+// renderer returns the configured Renderer or the default HTMLRenderer
+func (option *RenderOption) renderer() Renderer {
+	if option.Renderer != nil {
+		return option.Renderer
+	}
+	return HTMLRenderer{}
+}
+
+// nameSeparator returns the configured separator of nested field names
+func (option *RenderOption) nameSeparator() string {
+	return cmp.Or(option.NameSeparator, defaultNameSeparator)
+}
+
+// context returns the configured context of ChoicesProviders
+func (option *RenderOption) context() context.Context {
+	if option.Context != nil {
+		return option.Context
+	}
+	return context.Background()
 }
 
 func ConsolidateOptions(opts ...RenderOption) *RenderOption {
@@ -100,3 +279,77 @@ func ConsolidateOptions(opts ...RenderOption) *RenderOption {
 	}
 	return target
 }
+
+// BindNameSeparatorOption binds nested struct fields from names joined with
+// sep instead of ".". It must match the NameSeparatorOption used for rendering.
+func BindNameSeparatorOption(sep string) BindOption {
+	return BindOption{
+		NameSeparator: sep,
+	}
+}
+
+// BindMaxMemoryOption sets the number of bytes of uploaded files kept in memory.
+func BindMaxMemoryOption(maxMemory int64) BindOption {
+	return BindOption{
+		MaxMemory: maxMemory,
+	}
+}
+
+// BindLocationOption parses submitted datetime-local and time values in the
+// time zone loc. It should match the LocationOption used for rendering.
+func BindLocationOption(loc *time.Location) BindOption {
+	return BindOption{
+		Location: loc,
+	}
+}
+
+// BindContextOption passes ctx to the ChoicesProviders of the bound struct.
+func BindContextOption(ctx context.Context) BindOption {
+	return BindOption{
+		Context: ctx,
+	}
+}
+
+func (option *BindOption) apply(other BindOption) {
+	if other.NameSeparator != "" {
+		option.NameSeparator = other.NameSeparator
+	}
+	if other.MaxMemory != 0 {
+		option.MaxMemory = other.MaxMemory
+	}
+	if other.Location != nil {
+		option.Location = other.Location
+	}
+	if other.Context != nil {
+		option.Context = other.Context
+	}
+	if other.choicesField != "" {
+		option.choicesField = other.choicesField
+	}
+}
+
+// nameSeparator returns the configured separator of nested field names
+func (option *BindOption) nameSeparator() string {
+	return cmp.Or(option.NameSeparator, defaultNameSeparator)
+}
+
+// context returns the configured context of ChoicesProviders
+func (option *BindOption) context() context.Context {
+	if option.Context != nil {
+		return option.Context
+	}
+	return context.Background()
+}
+
+// maxMemory returns the configured memory limit of multipart forms
+func (option *BindOption) maxMemory() int64 {
+	return cmp.Or(option.MaxMemory, defaultMaxMemory)
+}
+
+func consolidateBindOptions(opts ...BindOption) *BindOption {
+	target := &BindOption{}
+	for _, opt := range opts {
+		target.apply(opt)
+	}
+	return target
+}